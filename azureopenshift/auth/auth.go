@@ -2,14 +2,21 @@ package auth
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
 	"log"
-	"strings"
+	"net/http"
+	"os"
+	"sync"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
 const (
@@ -20,12 +27,46 @@ const (
 	// AzureChinaString        = "china"
 )
 
+const (
+	AuthMethodAuto              = "auto"
+	AuthMethodClientCertificate = "client_certificate"
+	AuthMethodClientSecret      = "client_secret"
+	AuthMethodAzureCLI          = "azure_cli"
+)
+
 type Config struct {
 	SubscriptionId string
 	TenantId       string
 	ClientId       string
 	ClientSecret   string
 	Environment    string
+	AuthMethod     string
+
+	MetadataHost            string
+	ResourceManagerEndpoint string
+
+	MaxRetries    int32
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+	TryTimeout    time.Duration
+	ProxyURL      string
+	CABundlePath  string
+
+	PartnerId       string
+	UserAgentSuffix string
+
+	OperationRetryAttempts   int
+	OperationRetryMaxBackoff time.Duration
+
+	MaxConcurrentClusterCreates int
+
+	ClientCertificatePath     string
+	ClientCertificate         string
+	ClientCertificatePassword string
+
+	// AuthMethodConfigured is set when `auth_method` was set in the provider block, rather than defaulted or read
+	// from the environment
+	AuthMethodConfigured bool
 }
 
 type DefaultAroCredential struct {
@@ -33,45 +74,78 @@ type DefaultAroCredential struct {
 	options *azidentity.ClientSecretCredentialOptions
 }
 
-func NewDefaultAroCredential(config Config) (*DefaultAroCredential, error) {
+// NewDefaultAroCredential builds the credential chain used to authenticate against Azure.  When an AuthMethod is
+// configured only that credential is built, otherwise the configured client certificate and client secret are tried
+// in order, and the Azure CLI only when neither is set.  Every credential which could not be built is returned as an
+// error, as is the chain being unable to acquire a token.  The cloud metadata, if any, is fetched and the token
+// acquired with ctx.  The perCallPolicies are added to the pipeline of the token requests and shared with the ARO
+// clients.
+func NewDefaultAroCredential(ctx context.Context, config Config, perCallPolicies ...policy.Policy) (*DefaultAroCredential, diag.Diagnostics) {
 	var creds []azcore.TokenCredential
-	var errorMessages []string
+	var diags diag.Diagnostics
+
+	httpClient, err := newHTTPClient(config)
+	if err != nil {
+		return nil, diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Unable to configure the HTTP transport",
+			Detail:   err.Error(),
+		}}
+	}
+
+	cloudConfig, err := getCloud(ctx, config, httpClient)
+	if err != nil {
+		return nil, diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Unable to determine the Azure cloud environment",
+			Detail:   err.Error(),
+		}}
+	}
 
 	// create the credential with the options pointed to the appropriate selected cloud
 	cred := &DefaultAroCredential{
 		options: &azidentity.ClientSecretCredentialOptions{
 			ClientOptions: policy.ClientOptions{
-				Cloud: getCloud(config),
+				Cloud:           cloudConfig,
+				Retry:           retryOptions(config),
+				Transport:       httpClient,
+				PerCallPolicies: perCallPolicies,
 			},
+			// instance discovery always queries the public cloud, which clouds discovered through their metadata, such
+			// as disconnected clouds, don't use
+			DisableInstanceDiscovery: metadataEndpoint(config) != "",
 		},
 	}
 
-	clientSecretCred, err := azidentity.NewClientSecretCredential(config.TenantId, config.ClientId, config.ClientSecret, cred.options)
-	if err == nil {
-		creds = append(creds, clientSecretCred)
-	} else {
-		errorMessages = append(errorMessages, "AroClientSecretCredential: "+err.Error())
-	}
+	for _, method := range authMethods(config) {
+		methodCred, err := newCredential(method, config, cred.options)
+		if err != nil {
+			diags = append(diags, credentialDiagnostic(config, fmt.Sprintf("Unable to initialise the %q credential", method), err))
+			continue
+		}
 
-	cliCred, err := azidentity.NewAzureCLICredential(nil)
-	if err == nil {
-		creds = append(creds, cliCred)
-	} else {
-		errorMessages = append(errorMessages, "AroCLICredential: "+err.Error())
+		creds = append(creds, &namedCredential{name: method, cred: methodCred})
 	}
 
-	if err := defaultAroCredentialConstructorErrorHandler(len(creds), errorMessages); err != nil {
-		return nil, err
+	if diags.HasError() {
+		return nil, diags
 	}
 
 	chain, err := azidentity.NewChainedTokenCredential(creds, nil)
 	if err != nil {
-		return cred, err
+		return nil, append(diags, diag.FromErr(err)...)
 	}
 
 	cred.chain = chain
 
-	return cred, nil
+	// acquire a token up front, so that credentials Azure rejects are reported while configuring the provider rather
+	// than as the error of the first request
+	scope := cloudConfig.Services[cloud.ResourceManager].Audience + "/.default"
+	if _, err := cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{scope}}); err != nil {
+		return nil, append(diags, credentialDiagnostic(config, "Unable to acquire an Azure access token", err))
+	}
+
+	return cred, diags
 }
 
 // GetToken requests an access token from Azure Active Directory. This method is called automatically by Azure SDK clients.
@@ -89,28 +163,136 @@ func (c *DefaultAroCredential) GetClientOptions() *policy.ClientOptions {
 	return &c.options.ClientOptions
 }
 
-func defaultAroCredentialConstructorErrorHandler(numberOfSuccessfulCredentials int, errorMessages []string) (err error) {
-	errorMessage := strings.Join(errorMessages, "\n\t")
+// newClientCertificateCredential loads the PFX or PEM bundle from either the configured path or the
+// base64 encoded value and builds a credential for it.
+func newClientCertificateCredential(config Config, options *azidentity.ClientSecretCredentialOptions) (*azidentity.ClientCertificateCredential, error) {
+	certData, err := readClientCertificate(config)
+	if err != nil {
+		return nil, err
+	}
 
-	if numberOfSuccessfulCredentials == 0 {
-		return errors.New(errorMessage)
+	var password []byte
+	if config.ClientCertificatePassword != "" {
+		password = []byte(config.ClientCertificatePassword)
 	}
 
-	if len(errorMessages) != 0 {
-		log.Printf("NewDefaultAroCredential failed to initialize some credentials:\n\t%s", errorMessage)
+	certs, key, err := azidentity.ParseCertificates(certData, password)
+	if err != nil {
+		return nil, fmt.Errorf("parsing client certificate: %w", err)
 	}
 
-	return nil
+	return azidentity.NewClientCertificateCredential(config.TenantId, config.ClientId, certs, key, &azidentity.ClientCertificateCredentialOptions{
+		ClientOptions:            options.ClientOptions,
+		DisableInstanceDiscovery: options.DisableInstanceDiscovery,
+	})
 }
 
-func getCloud(config Config) cloud.Configuration {
+func readClientCertificate(config Config) ([]byte, error) {
+	if config.ClientCertificatePath != "" && config.ClientCertificate != "" {
+		return nil, errors.New("only one of `client_certificate_path` and `client_certificate` can be specified")
+	}
+
+	if config.ClientCertificate != "" {
+		certData, err := base64.StdEncoding.DecodeString(config.ClientCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("decoding base64 client certificate: %w", err)
+		}
+		return certData, nil
+	}
+
+	certData, err := os.ReadFile(config.ClientCertificatePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading client certificate %q: %w", config.ClientCertificatePath, err)
+	}
+	return certData, nil
+}
+
+// authMethods returns the authentication methods to try, in order, for the given configuration.  Unless requested,
+// the Azure CLI is only used when no Service Principal credential is configured, so that a broken Service Principal
+// never silently falls back to a personal login.
+func authMethods(config Config) []string {
+	if config.AuthMethod != "" && config.AuthMethod != AuthMethodAuto {
+		return []string{config.AuthMethod}
+	}
+
+	var methods []string
+	if config.ClientCertificatePath != "" || config.ClientCertificate != "" {
+		methods = append(methods, AuthMethodClientCertificate)
+	}
+	if config.ClientSecret != "" {
+		methods = append(methods, AuthMethodClientSecret)
+	}
+	if len(methods) == 0 {
+		methods = append(methods, AuthMethodAzureCLI)
+	}
+
+	return methods
+}
+
+func newCredential(method string, config Config, options *azidentity.ClientSecretCredentialOptions) (azcore.TokenCredential, error) {
+	switch method {
+	case AuthMethodClientCertificate:
+		return newClientCertificateCredential(config, options)
+	case AuthMethodClientSecret:
+		if config.ClientSecret == "" {
+			return nil, errors.New("`client_secret` was not specified")
+		}
+		return azidentity.NewClientSecretCredential(config.TenantId, config.ClientId, config.ClientSecret, options)
+	case AuthMethodAzureCLI:
+		return azidentity.NewAzureCLICredential(&azidentity.AzureCLICredentialOptions{TenantID: config.TenantId})
+	default:
+		return nil, fmt.Errorf("unsupported authentication method %q", method)
+	}
+}
+
+// credentialDiagnostic describes a credential which is unusable.  Only the configured credentials are tried, so it is
+// always an error, pointing at `auth_method` when the method was selected there rather than defaulted.
+func credentialDiagnostic(config Config, summary string, err error) diag.Diagnostic {
+	d := diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  summary,
+		Detail:   err.Error(),
+	}
+	if config.AuthMethodConfigured {
+		d.AttributePath = cty.GetAttrPath("auth_method")
+	}
+
+	return d
+}
+
+// namedCredential records which link of the chain was used to authenticate.
+type namedCredential struct {
+	name   string
+	cred   azcore.TokenCredential
+	logged sync.Once
+}
+
+func (c *namedCredential) GetToken(ctx context.Context, opts policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	token, err := c.cred.GetToken(ctx, opts)
+	if err != nil {
+		log.Printf("[DEBUG] authenticating using the %q credential failed: %s", c.name, err)
+		return token, err
+	}
+
+	c.logged.Do(func() {
+		log.Printf("[INFO] authenticated to Azure using the %q credential", c.name)
+	})
+
+	return token, nil
+}
+
+func getCloud(ctx context.Context, config Config, httpClient *http.Client) (cloud.Configuration, error) {
+	if endpoint := metadataEndpoint(config); endpoint != "" {
+		return cloudFromMetadata(ctx, httpClient, endpoint, config.Environment)
+	}
+
 	switch config.Environment {
 	// TODO: remove China support for now until ARO supports it.
 	// case AzureChinaString:
-	// 	return cloud.AzureChina
+	// 	return cloud.AzureChina, nil
 	case AzureUSGovernmentString:
-		return cloud.AzureGovernment
+		return cloud.AzureGovernment, nil
 	default:
-		return cloud.AzurePublic
+		return cloud.AzurePublic, nil
 	}
 }
@@ -0,0 +1,252 @@
+package auth
+
+import (
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/hashicorp/go-cty/cty"
+)
+
+// the certificates in testdata are self-signed, the PFX bundle being protected by testCertificatePassword
+const testCertificatePassword = "fake-password"
+
+func TestNewClientCertificateCredential(t *testing.T) {
+	pem, err := os.ReadFile(filepath.Join("testdata", "client.pem"))
+	if err != nil {
+		t.Fatalf("reading PEM bundle: %+v", err)
+	}
+	pfx, err := os.ReadFile(filepath.Join("testdata", "client.pfx"))
+	if err != nil {
+		t.Fatalf("reading PFX bundle: %+v", err)
+	}
+
+	testCases := []struct {
+		desc      string
+		config    Config
+		expectErr string
+	}{
+		{
+			desc:   "PEM bundles are loaded from their path",
+			config: Config{ClientCertificatePath: filepath.Join("testdata", "client.pem")},
+		},
+		{
+			desc:   "base64 encoded PEM bundles are loaded",
+			config: Config{ClientCertificate: base64.StdEncoding.EncodeToString(pem)},
+		},
+		{
+			desc:   "PFX bundles are decrypted with the password",
+			config: Config{ClientCertificatePath: filepath.Join("testdata", "client.pfx"), ClientCertificatePassword: testCertificatePassword},
+		},
+		{
+			desc:   "base64 encoded PFX bundles are decrypted with the password",
+			config: Config{ClientCertificate: base64.StdEncoding.EncodeToString(pfx), ClientCertificatePassword: testCertificatePassword},
+		},
+		{
+			desc:      "PFX bundles aren't decrypted with the wrong password",
+			config:    Config{ClientCertificatePath: filepath.Join("testdata", "client.pfx"), ClientCertificatePassword: "wrong-password"},
+			expectErr: "parsing client certificate",
+		},
+		{
+			desc:      "PFX bundles aren't decrypted without a password",
+			config:    Config{ClientCertificatePath: filepath.Join("testdata", "client.pfx")},
+			expectErr: "parsing client certificate",
+		},
+		{
+			desc:      "Only one of the path and the value can be specified",
+			config:    Config{ClientCertificatePath: filepath.Join("testdata", "client.pem"), ClientCertificate: base64.StdEncoding.EncodeToString(pem)},
+			expectErr: "only one of",
+		},
+		{
+			desc:      "Missing files are reported",
+			config:    Config{ClientCertificatePath: filepath.Join("testdata", "missing.pem")},
+			expectErr: "reading client certificate",
+		},
+		{
+			desc:      "Values which aren't base64 are reported",
+			config:    Config{ClientCertificate: "not base64!"},
+			expectErr: "decoding base64 client certificate",
+		},
+		{
+			desc:      "Values which aren't certificates are reported",
+			config:    Config{ClientCertificate: base64.StdEncoding.EncodeToString([]byte("not a certificate"))},
+			expectErr: "parsing client certificate",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			test.config.TenantId = "11111111-1111-1111-1111-111111111111"
+			test.config.ClientId = "22222222-2222-2222-2222-222222222222"
+
+			cred, err := newClientCertificateCredential(test.config, &azidentity.ClientSecretCredentialOptions{})
+			if test.expectErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.expectErr) {
+					t.Fatalf("Expected an error containing %q - got %v", test.expectErr, err)
+				}
+				return
+			}
+			if err != nil || cred == nil {
+				t.Fatalf("Expected a credential - got %+v", err)
+			}
+		})
+	}
+}
+
+func TestAuthMethods(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config Config
+		expect []string
+	}{
+		{
+			desc:   "Explicit methods are used alone",
+			config: Config{AuthMethod: AuthMethodClientSecret, ClientSecret: "secret", ClientCertificatePath: "client.pem"},
+			expect: []string{AuthMethodClientSecret},
+		},
+		{
+			desc:   "Explicit methods are used even when not configured",
+			config: Config{AuthMethod: AuthMethodClientCertificate},
+			expect: []string{AuthMethodClientCertificate},
+		},
+		{
+			desc:   "Explicit Azure CLI ignores the configured secrets",
+			config: Config{AuthMethod: AuthMethodAzureCLI, ClientSecret: "secret"},
+			expect: []string{AuthMethodAzureCLI},
+		},
+		{
+			desc:   "Auto tries the certificate before the secret",
+			config: Config{AuthMethod: AuthMethodAuto, ClientSecret: "secret", ClientCertificatePath: "client.pem"},
+			expect: []string{AuthMethodClientCertificate, AuthMethodClientSecret},
+		},
+		{
+			desc:   "Auto uses base64 encoded certificates",
+			config: Config{AuthMethod: AuthMethodAuto, ClientCertificate: "Y2VydA=="},
+			expect: []string{AuthMethodClientCertificate},
+		},
+		{
+			desc:   "Auto uses the secret alone",
+			config: Config{AuthMethod: AuthMethodAuto, ClientSecret: "secret"},
+			expect: []string{AuthMethodClientSecret},
+		},
+		{
+			desc:   "Auto falls back to the Azure CLI",
+			config: Config{AuthMethod: AuthMethodAuto},
+			expect: []string{AuthMethodAzureCLI},
+		},
+		{
+			desc:   "No method behaves as auto",
+			config: Config{ClientSecret: "secret", ClientCertificatePath: "client.pem"},
+			expect: []string{AuthMethodClientCertificate, AuthMethodClientSecret},
+		},
+		{
+			desc:   "No method nor credentials falls back to the Azure CLI",
+			config: Config{},
+			expect: []string{AuthMethodAzureCLI},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			if methods := authMethods(test.config); !reflect.DeepEqual(methods, test.expect) {
+				t.Errorf("Expected %v - got %v", test.expect, methods)
+			}
+		})
+	}
+}
+
+func TestNewCredential(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		method    string
+		config    Config
+		expectErr string
+	}{
+		{
+			desc:   "Client certificate",
+			method: AuthMethodClientCertificate,
+			config: Config{ClientCertificatePath: filepath.Join("testdata", "client.pem")},
+		},
+		{
+			desc:      "Client certificate without a certificate",
+			method:    AuthMethodClientCertificate,
+			config:    Config{},
+			expectErr: "client certificate",
+		},
+		{
+			desc:   "Client secret",
+			method: AuthMethodClientSecret,
+			config: Config{ClientSecret: "secret"},
+		},
+		{
+			desc:      "Client secret without a secret",
+			method:    AuthMethodClientSecret,
+			config:    Config{},
+			expectErr: "`client_secret` was not specified",
+		},
+		{
+			desc:   "Azure CLI",
+			method: AuthMethodAzureCLI,
+			config: Config{},
+		},
+		{
+			desc:      "Unsupported method",
+			method:    "managed_identity",
+			config:    Config{},
+			expectErr: `unsupported authentication method "managed_identity"`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			test.config.TenantId = "11111111-1111-1111-1111-111111111111"
+			test.config.ClientId = "22222222-2222-2222-2222-222222222222"
+
+			cred, err := newCredential(test.method, test.config, &azidentity.ClientSecretCredentialOptions{})
+			if test.expectErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.expectErr) {
+					t.Fatalf("Expected an error containing %q - got %v", test.expectErr, err)
+				}
+				return
+			}
+			if err != nil || cred == nil {
+				t.Fatalf("Expected a credential - got %+v", err)
+			}
+		})
+	}
+}
+
+func TestCredentialDiagnostic(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		configured bool
+		expectPath cty.Path
+	}{
+		{
+			desc:       "Configured methods point at auth_method",
+			configured: true,
+			expectPath: cty.GetAttrPath("auth_method"),
+		},
+		{
+			desc:       "Defaulted methods point at no attribute",
+			configured: false,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			d := credentialDiagnostic(Config{AuthMethodConfigured: test.configured}, "summary", errors.New("detail"))
+			if d.Summary != "summary" || d.Detail != "detail" {
+				t.Errorf("Expected summary and detail to be kept - got %q and %q", d.Summary, d.Detail)
+			}
+			if !d.AttributePath.Equals(test.expectPath) {
+				t.Errorf("Expected path %#v - got %#v", test.expectPath, d.AttributePath)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+)
+
+const (
+	metadataApiVersion = "2022-09-01"
+	metadataTimeout    = 30 * time.Second
+)
+
+// metadataEnvironmentNames maps the provider's environment names to the names used in the ARM metadata document.
+var metadataEnvironmentNames = map[string]string{
+	AzurePublicString:       "AzureCloud",
+	AzureUSGovernmentString: "AzureUSGovernment",
+}
+
+// metadataEnvironment is the subset of the ARM metadata discovery document needed to build a cloud.Configuration.
+type metadataEnvironment struct {
+	Name            string `json:"name"`
+	ResourceManager string `json:"resourceManager"`
+	Authentication  struct {
+		LoginEndpoint string   `json:"loginEndpoint"`
+		Audiences     []string `json:"audiences"`
+		Tenant        string   `json:"tenant"`
+	} `json:"authentication"`
+}
+
+// metadataEndpoint returns the base URL of the ARM metadata discovery document for the configuration, or an empty
+// string when neither `metadata_host` nor `resource_manager_endpoint` was specified.
+func metadataEndpoint(config Config) string {
+	if config.ResourceManagerEndpoint != "" {
+		return strings.TrimSuffix(config.ResourceManagerEndpoint, "/")
+	}
+
+	if config.MetadataHost != "" {
+		return fmt.Sprintf("https://%s", strings.TrimSuffix(config.MetadataHost, "/"))
+	}
+
+	return ""
+}
+
+// cloudFromMetadata builds a cloud.Configuration from the ARM metadata discovery document served at endpoint.
+func cloudFromMetadata(ctx context.Context, client *http.Client, endpoint string, environment string) (cloud.Configuration, error) {
+	ctx, cancel := context.WithTimeout(ctx, metadataTimeout)
+	defer cancel()
+
+	uri := fmt.Sprintf("%s/metadata/endpoints?api-version=%s", endpoint, metadataApiVersion)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return cloud.Configuration{}, fmt.Errorf("building metadata request for %q: %w", uri, err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return cloud.Configuration{}, fmt.Errorf("retrieving metadata from %q: %w", uri, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return cloud.Configuration{}, fmt.Errorf("reading metadata from %q: %w", uri, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return cloud.Configuration{}, fmt.Errorf("retrieving metadata from %q: unexpected status %d: %s", uri, resp.StatusCode, string(body))
+	}
+
+	environments, err := parseMetadataEnvironments(body)
+	if err != nil {
+		return cloud.Configuration{}, fmt.Errorf("parsing metadata from %q: %w", uri, err)
+	}
+
+	env, err := selectMetadataEnvironment(environments, endpoint, environment)
+	if err != nil {
+		return cloud.Configuration{}, err
+	}
+
+	return env.cloudConfiguration()
+}
+
+// parseMetadataEnvironments accepts both the list of environments returned by the public clouds and the single
+// environment returned by Azure Stack and private clouds.
+func parseMetadataEnvironments(body []byte) ([]metadataEnvironment, error) {
+	var environments []metadataEnvironment
+	if err := json.Unmarshal(body, &environments); err == nil {
+		return environments, nil
+	}
+
+	var environment metadataEnvironment
+	if err := json.Unmarshal(body, &environment); err != nil {
+		return nil, err
+	}
+
+	return []metadataEnvironment{environment}, nil
+}
+
+// selectMetadataEnvironment picks the environment serving the metadata endpoint, falling back to the environment
+// matching the configured `environment` name.
+func selectMetadataEnvironment(environments []metadataEnvironment, endpoint string, environment string) (*metadataEnvironment, error) {
+	if len(environments) == 1 {
+		return &environments[0], nil
+	}
+
+	for i, env := range environments {
+		if sameHost(env.ResourceManager, endpoint) {
+			return &environments[i], nil
+		}
+	}
+
+	name := environment
+	if v, ok := metadataEnvironmentNames[environment]; ok {
+		name = v
+	}
+
+	names := make([]string, 0, len(environments))
+	for i, env := range environments {
+		if strings.EqualFold(env.Name, name) {
+			return &environments[i], nil
+		}
+		names = append(names, env.Name)
+	}
+
+	return nil, fmt.Errorf("no environment named %q was found in the metadata, available environments: %s", name, strings.Join(names, ", "))
+}
+
+func (env metadataEnvironment) cloudConfiguration() (cloud.Configuration, error) {
+	if env.ResourceManager == "" {
+		return cloud.Configuration{}, fmt.Errorf("environment %q did not specify a `resourceManager` endpoint", env.Name)
+	}
+
+	if env.Authentication.LoginEndpoint == "" {
+		return cloud.Configuration{}, fmt.Errorf("environment %q did not specify an `authentication.loginEndpoint`", env.Name)
+	}
+
+	audience := env.ResourceManager
+	if len(env.Authentication.Audiences) > 0 {
+		audience = env.Authentication.Audiences[0]
+	}
+
+	return cloud.Configuration{
+		ActiveDirectoryAuthorityHost: env.Authentication.LoginEndpoint,
+		Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+			cloud.ResourceManager: {
+				Audience: audience,
+				Endpoint: env.ResourceManager,
+			},
+		},
+	}, nil
+}
+
+func sameHost(a, b string) bool {
+	ua, err := url.Parse(a)
+	if err != nil {
+		return false
+	}
+
+	ub, err := url.Parse(b)
+	if err != nil {
+		return false
+	}
+
+	return ua.Host != "" && strings.EqualFold(ua.Host, ub.Host)
+}
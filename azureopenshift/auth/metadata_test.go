@@ -0,0 +1,245 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+)
+
+// testMetadataServer serves body as the ARM metadata document, with its {{server}} placeholder replaced by the server URL.
+func testMetadataServer(t *testing.T, status int, body string) *httptest.Server {
+	t.Helper()
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metadata/endpoints" || r.URL.Query().Get("api-version") != metadataApiVersion {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		fmt.Fprint(w, strings.ReplaceAll(body, "{{server}}", server.URL))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestCloudFromMetadata(t *testing.T) {
+	public := `{"name": "AzureCloud", "resourceManager": "https://management.azure.com/", "authentication": {"loginEndpoint": "https://login.microsoftonline.com/", "audiences": ["https://management.core.windows.net/"]}}`
+	usGovernment := `{"name": "AzureUSGovernment", "resourceManager": "https://management.usgovcloudapi.net/", "authentication": {"loginEndpoint": "https://login.microsoftonline.us/", "audiences": ["https://management.core.usgovcloudapi.net/"]}}`
+	private := `{"name": "Private", "resourceManager": "https://management.private.example/", "authentication": {"loginEndpoint": "https://login.private.example/", "audiences": ["https://management.private.example/audience"]}}`
+	served := `{"name": "Served", "resourceManager": "{{server}}/", "authentication": {"loginEndpoint": "https://login.served.example/", "audiences": ["https://management.served.example/"]}}`
+
+	testCases := []struct {
+		desc        string
+		status      int
+		body        string
+		environment string
+		expect      func(serverURL string) cloud.Configuration
+		expectErr   string
+	}{
+		{
+			desc:   "A single environment is used regardless of its host",
+			status: http.StatusOK,
+			body:   private,
+			expect: func(string) cloud.Configuration {
+				return testCloudConfiguration("https://login.private.example/", "https://management.private.example/audience", "https://management.private.example/")
+			},
+		},
+		{
+			desc:   "The resource manager endpoint is the audience when none is listed",
+			status: http.StatusOK,
+			body:   `{"name": "AzureStack", "resourceManager": "https://management.stack.example/", "authentication": {"loginEndpoint": "https://login.stack.example/"}}`,
+			expect: func(string) cloud.Configuration {
+				return testCloudConfiguration("https://login.stack.example/", "https://management.stack.example/", "https://management.stack.example/")
+			},
+		},
+		{
+			desc:        "The environment serving the endpoint is preferred to the environment name",
+			status:      http.StatusOK,
+			body:        "[" + public + "," + served + "]",
+			environment: AzurePublicString,
+			expect: func(serverURL string) cloud.Configuration {
+				return testCloudConfiguration("https://login.served.example/", "https://management.served.example/", serverURL+"/")
+			},
+		},
+		{
+			desc:        "The public environment is selected by its metadata name",
+			status:      http.StatusOK,
+			body:        "[" + usGovernment + "," + public + "]",
+			environment: AzurePublicString,
+			expect: func(string) cloud.Configuration {
+				return testCloudConfiguration("https://login.microsoftonline.com/", "https://management.core.windows.net/", "https://management.azure.com/")
+			},
+		},
+		{
+			desc:        "The US government environment is selected by its metadata name",
+			status:      http.StatusOK,
+			body:        "[" + public + "," + usGovernment + "]",
+			environment: AzureUSGovernmentString,
+			expect: func(string) cloud.Configuration {
+				return testCloudConfiguration("https://login.microsoftonline.us/", "https://management.core.usgovcloudapi.net/", "https://management.usgovcloudapi.net/")
+			},
+		},
+		{
+			desc:        "Unmapped environment names are matched case insensitively",
+			status:      http.StatusOK,
+			body:        "[" + public + "," + private + "]",
+			environment: "private",
+			expect: func(string) cloud.Configuration {
+				return testCloudConfiguration("https://login.private.example/", "https://management.private.example/audience", "https://management.private.example/")
+			},
+		},
+		{
+			desc:        "Unknown environments list the available ones",
+			status:      http.StatusOK,
+			body:        "[" + public + "," + usGovernment + "]",
+			environment: "missing",
+			expectErr:   `no environment named "missing" was found in the metadata, available environments: AzureCloud, AzureUSGovernment`,
+		},
+		{
+			desc:      "Environments without a resource manager endpoint are rejected",
+			status:    http.StatusOK,
+			body:      `{"name": "AzureStack", "authentication": {"loginEndpoint": "https://login.stack.example/"}}`,
+			expectErr: "did not specify a `resourceManager` endpoint",
+		},
+		{
+			desc:      "Environments without a login endpoint are rejected",
+			status:    http.StatusOK,
+			body:      `{"name": "AzureStack", "resourceManager": "https://management.stack.example/"}`,
+			expectErr: "did not specify an `authentication.loginEndpoint`",
+		},
+		{
+			desc:      "Documents which aren't JSON are rejected",
+			status:    http.StatusOK,
+			body:      `not json`,
+			expectErr: "parsing metadata",
+		},
+		{
+			desc:      "Unexpected statuses are reported",
+			status:    http.StatusInternalServerError,
+			body:      `unavailable`,
+			expectErr: "unexpected status 500: unavailable",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			server := testMetadataServer(t, test.status, test.body)
+
+			config, err := cloudFromMetadata(context.Background(), server.Client(), server.URL, test.environment)
+			if test.expectErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.expectErr) {
+					t.Fatalf("Expected an error containing %q - got %v", test.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected a cloud configuration - got %+v", err)
+			}
+			if expect := test.expect(server.URL); !reflect.DeepEqual(config, expect) {
+				t.Errorf("Expected %+v - got %+v", expect, config)
+			}
+		})
+	}
+}
+
+func TestMetadataEndpoint(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config Config
+		expect string
+	}{
+		{
+			desc:   "No endpoint without metadata_host nor resource_manager_endpoint",
+			config: Config{},
+			expect: "",
+		},
+		{
+			desc:   "metadata_host is served over HTTPS",
+			config: Config{MetadataHost: "management.private.example/"},
+			expect: "https://management.private.example",
+		},
+		{
+			desc:   "resource_manager_endpoint is used as is",
+			config: Config{ResourceManagerEndpoint: "https://management.stack.example/"},
+			expect: "https://management.stack.example",
+		},
+		{
+			desc:   "resource_manager_endpoint takes precedence over metadata_host",
+			config: Config{MetadataHost: "management.private.example", ResourceManagerEndpoint: "https://management.stack.example"},
+			expect: "https://management.stack.example",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			if endpoint := metadataEndpoint(test.config); endpoint != test.expect {
+				t.Errorf("Expected %q - got %q", test.expect, endpoint)
+			}
+		})
+	}
+}
+
+func TestGetCloud(t *testing.T) {
+	server := testMetadataServer(t, http.StatusOK, `{"name": "AzureStack", "resourceManager": "{{server}}/", "authentication": {"loginEndpoint": "https://login.stack.example/"}}`)
+
+	testCases := []struct {
+		desc   string
+		config Config
+		expect cloud.Configuration
+	}{
+		{
+			desc:   "Public by default",
+			config: Config{},
+			expect: cloud.AzurePublic,
+		},
+		{
+			desc:   "Public",
+			config: Config{Environment: AzurePublicString},
+			expect: cloud.AzurePublic,
+		},
+		{
+			desc:   "US government",
+			config: Config{Environment: AzureUSGovernmentString},
+			expect: cloud.AzureGovernment,
+		},
+		{
+			desc:   "Discovered from the metadata, ignoring the environment",
+			config: Config{Environment: AzureUSGovernmentString, ResourceManagerEndpoint: server.URL},
+			expect: testCloudConfiguration("https://login.stack.example/", server.URL+"/", server.URL+"/"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config, err := getCloud(context.Background(), test.config, server.Client())
+			if err != nil {
+				t.Fatalf("Expected a cloud configuration - got %+v", err)
+			}
+			if !reflect.DeepEqual(config, test.expect) {
+				t.Errorf("Expected %+v - got %+v", test.expect, config)
+			}
+		})
+	}
+}
+
+func testCloudConfiguration(loginEndpoint, audience, endpoint string) cloud.Configuration {
+	return cloud.Configuration{
+		ActiveDirectoryAuthorityHost: loginEndpoint,
+		Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+			cloud.ResourceManager: {
+				Audience: audience,
+				Endpoint: endpoint,
+			},
+		},
+	}
+}
@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// newHTTPClient builds the HTTP client shared by the token credentials and the ARO clients, honouring the configured
+// proxy and extra CA bundle.
+func newHTTPClient(config Config) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if config.ProxyURL != "" {
+		proxyURL, err := url.Parse(config.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("parsing proxy URL %q: %w", config.ProxyURL, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if config.CABundlePath != "" {
+		rootCAs, err := loadCABundle(config.CABundlePath)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig.RootCAs = rootCAs
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
+
+// loadCABundle appends the PEM encoded certificates at path to the system certificate pool.
+func loadCABundle(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading CA bundle %q: %w", path, err)
+	}
+
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil || rootCAs == nil {
+		rootCAs = x509.NewCertPool()
+	}
+
+	if !rootCAs.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("CA bundle %q did not contain any PEM encoded certificates", path)
+	}
+
+	return rootCAs, nil
+}
+
+// retryOptions converts the configured retry settings into the SDK's retry policy options.  A MaxRetries of 0
+// disables retries, whilst zero durations leave the SDK defaults in place.
+func retryOptions(config Config) policy.RetryOptions {
+	maxRetries := config.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = -1
+	}
+
+	return policy.RetryOptions{
+		MaxRetries:    maxRetries,
+		TryTimeout:    config.TryTimeout,
+		RetryDelay:    config.RetryDelay,
+		MaxRetryDelay: config.MaxRetryDelay,
+	}
+}
@@ -0,0 +1,151 @@
+package auth
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+func TestRetryOptions(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config Config
+		expect policy.RetryOptions
+	}{
+		{
+			desc:   "No retries disables the SDK retries",
+			config: Config{MaxRetries: 0},
+			expect: policy.RetryOptions{MaxRetries: -1},
+		},
+		{
+			desc:   "Retries are passed through",
+			config: Config{MaxRetries: 5},
+			expect: policy.RetryOptions{MaxRetries: 5},
+		},
+		{
+			desc:   "Delays and timeouts are passed through",
+			config: Config{MaxRetries: 3, RetryDelay: 2 * time.Second, MaxRetryDelay: time.Minute, TryTimeout: 5 * time.Minute},
+			expect: policy.RetryOptions{MaxRetries: 3, RetryDelay: 2 * time.Second, MaxRetryDelay: time.Minute, TryTimeout: 5 * time.Minute},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			if options := retryOptions(test.config); !reflect.DeepEqual(options, test.expect) {
+				t.Errorf("Expected %+v - got %+v", test.expect, options)
+			}
+		})
+	}
+}
+
+func TestNewHTTPClient(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// the proxy answers requests for any host, recording the host it was asked for
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.Host
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer proxy.Close()
+
+	dir := t.TempDir()
+	caBundle := filepath.Join(dir, "ca.pem")
+	if err := os.WriteFile(caBundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600); err != nil {
+		t.Fatalf("writing CA bundle: %+v", err)
+	}
+	emptyBundle := filepath.Join(dir, "empty.pem")
+	if err := os.WriteFile(emptyBundle, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("writing CA bundle: %+v", err)
+	}
+
+	testCases := []struct {
+		desc          string
+		config        Config
+		url           string
+		expectProxied string
+		expectErr     string
+		expectGetErr  string
+	}{
+		{
+			desc:         "Servers signed by unknown authorities are rejected",
+			config:       Config{},
+			url:          server.URL,
+			expectGetErr: "certificate",
+		},
+		{
+			desc:   "Servers signed by the CA bundle are trusted",
+			config: Config{CABundlePath: caBundle},
+			url:    server.URL,
+		},
+		{
+			desc:      "CA bundles without certificates are rejected",
+			config:    Config{CABundlePath: emptyBundle},
+			expectErr: "did not contain any PEM encoded certificates",
+		},
+		{
+			desc:      "Missing CA bundles are rejected",
+			config:    Config{CABundlePath: filepath.Join(dir, "missing.pem")},
+			expectErr: "reading CA bundle",
+		},
+		{
+			desc:          "Requests are sent through the proxy",
+			config:        Config{ProxyURL: proxy.URL},
+			url:           "http://management.private.example/",
+			expectProxied: "management.private.example",
+		},
+		{
+			desc:      "Invalid proxy URLs are rejected",
+			config:    Config{ProxyURL: "http://proxy.example:port"},
+			expectErr: "parsing proxy URL",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			proxied = ""
+
+			client, err := newHTTPClient(test.config)
+			if test.expectErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.expectErr) {
+					t.Fatalf("Expected an error containing %q - got %v", test.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected an HTTP client - got %+v", err)
+			}
+			if minVersion := client.Transport.(*http.Transport).TLSClientConfig.MinVersion; minVersion != tls.VersionTLS12 {
+				t.Errorf("Expected TLS 1.2 as the minimum version - got %x", minVersion)
+			}
+
+			resp, err := client.Get(test.url)
+			if test.expectGetErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.expectGetErr) {
+					t.Fatalf("Expected an error containing %q - got %v", test.expectGetErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected a response - got %+v", err)
+			}
+			resp.Body.Close()
+
+			if proxied != test.expectProxied {
+				t.Errorf("Expected the request for %q to be proxied - got %q", test.expectProxied, proxied)
+			}
+		})
+	}
+}
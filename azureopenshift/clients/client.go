@@ -3,34 +3,120 @@ package clients
 import (
 	"context"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	armpolicy "github.com/Azure/azure-sdk-for-go/sdk/azcore/arm/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	redhatopenshift "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/redhatopenshift/armredhatopenshift"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armlocks"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/auth"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/azure"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/locks"
 )
 
 type Client struct {
-	OpenShiftClustersClient *redhatopenshift.OpenShiftClustersClient
-	SubscriptionID          string
-	StopCtx                 context.Context
+	OpenShiftClustersClient    OpenShiftClustersClient
+	OpenShiftCredentialsClient OpenShiftCredentialsClient
+	ResourcesClient            *armresources.Client
+	TagsClient                 *armresources.TagsClient
+	ManagementLocksClient      ManagementLocksClient
+	SubscriptionID             string
+	StopCtx                    context.Context
+	RetryOptions               RetryOptions
+
+	// DeletionProtectionTag is the key of the tag which protects a cluster from being deleted
+	DeletionProtectionTag string
+
+	// TagsConfig holds the provider's `default_tags` and `ignore_tags`
+	TagsConfig azure.TagsConfig
+
+	// CreateSemaphore caps the number of clusters created concurrently in the subscription, across every provider
+	// configured for it
+	CreateSemaphore locks.Semaphore
 }
 
-func NewClient(stopCtx context.Context, config auth.Config) (*Client, error) {
-	cred, err := auth.NewDefaultAroCredential(config)
+// NewClient builds the clients of the provider.  ctx bounds the requests made while configuring, such as fetching the
+// cloud metadata, while stopCtx is kept for the operations of the resources.
+func NewClient(ctx context.Context, stopCtx context.Context, config auth.Config) (*Client, diag.Diagnostics) {
+	recording, err := recordingConfigFromEnv(config.SubscriptionId)
 	if err != nil {
-		return nil, err
+		return nil, diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Unable to configure the recording of the ARM traffic",
+			Detail:   err.Error(),
+		}}
 	}
 
-	options := &armpolicy.ClientOptions{ClientOptions: *cred.GetClientOptions()}
+	var diags diag.Diagnostics
+	var cred azcore.TokenCredential
+	var clientOptions policy.ClientOptions
+
+	userAgentPolicy := newUserAgentPolicy(config.PartnerId, config.UserAgentSuffix)
+	if recording.mode == recordingModeReplay {
+		// replayed clients never reach Azure, so they don't need a credential
+		cred, clientOptions, err = newReplayClientOptions(recording, userAgentPolicy)
+		if err != nil {
+			return nil, diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "Unable to replay the ARM traffic",
+				Detail:   err.Error(),
+			}}
+		}
+	} else {
+		aroCred, credDiags := auth.NewDefaultAroCredential(ctx, config, userAgentPolicy)
+		diags = credDiags
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		cred, clientOptions = aroCred, *aroCred.GetClientOptions()
+		if recording.mode == recordingModeRecord {
+			clientOptions.Transport = newRecordingTransport(recording, clientOptions.Transport)
+		}
+	}
+
+	options := &armpolicy.ClientOptions{ClientOptions: clientOptions}
+	options.PerRetryPolicies = append(options.PerRetryPolicies, newLoggingPolicy())
+
+	// only the requests of the ARO resource provider are recorded as the operation, as those are the identifiers
+	// Microsoft support asks for
+	openshiftClustersOptions := *options
+	openshiftClustersOptions.PerRetryPolicies = append([]policy.Policy{newOperationIdsPolicy()}, options.PerRetryPolicies...)
+
+	openshiftClustersClient, err := redhatopenshift.NewOpenShiftClustersClient(config.SubscriptionId, cred, &openshiftClustersOptions)
+	if err != nil {
+		return nil, append(diags, diag.FromErr(err)...)
+	}
+
+	resourcesClient, err := armresources.NewClient(config.SubscriptionId, cred, options)
+	if err != nil {
+		return nil, append(diags, diag.FromErr(err)...)
+	}
+
+	tagsClient, err := armresources.NewTagsClient(config.SubscriptionId, cred, options)
+	if err != nil {
+		return nil, append(diags, diag.FromErr(err)...)
+	}
 
-	openshiftClustersClient, err := redhatopenshift.NewOpenShiftClustersClient(config.SubscriptionId, cred, options)
+	managementLocksClient, err := armlocks.NewManagementLocksClient(config.SubscriptionId, cred, options)
 	if err != nil {
-		return nil, err
+		return nil, append(diags, diag.FromErr(err)...)
 	}
 
 	return &Client{
-		OpenShiftClustersClient: openshiftClustersClient,
-		StopCtx:                 stopCtx,
-		SubscriptionID:          config.SubscriptionId,
-	}, nil
+		OpenShiftClustersClient:    newSDKOpenShiftClustersClient(openshiftClustersClient),
+		OpenShiftCredentialsClient: newSDKOpenShiftClustersClient(openshiftClustersClient),
+		ResourcesClient:            resourcesClient,
+		TagsClient:                 tagsClient,
+		ManagementLocksClient:      managementLocksClient,
+		StopCtx:                    stopCtx,
+		SubscriptionID:             config.SubscriptionId,
+		RetryOptions: RetryOptions{
+			Attempts:   config.OperationRetryAttempts,
+			MaxBackoff: config.OperationRetryMaxBackoff,
+		},
+		CreateSemaphore: locks.BySubscription(config.SubscriptionId, config.MaxConcurrentClusterCreates),
+	}, diags
 }
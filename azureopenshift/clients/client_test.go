@@ -0,0 +1,38 @@
+package clients_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/clients"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/fake"
+)
+
+func TestNewClient_OperationIds(t *testing.T) {
+	server := fake.NewServer(fake.Options{})
+	defer server.Close()
+	caBundlePath := filepath.Join(t.TempDir(), "ca.pem")
+	if err := server.WriteCABundle(caBundlePath); err != nil {
+		t.Fatalf("writing CA bundle: %+v", err)
+	}
+
+	client, diags := clients.NewClient(context.Background(), context.Background(), server.AuthConfig(caBundlePath))
+	if diags.HasError() {
+		t.Fatalf("building client: %+v", diags)
+	}
+
+	clusterId := "/subscriptions/" + fake.SubscriptionID + "/resourceGroups/test-rg/providers/Microsoft.RedHatOpenShift/openShiftClusters/test-cluster"
+	ctx, tracker := clients.WithOperationTracker(context.Background())
+
+	// the requests of other resource providers aren't the cluster's operation
+	_, _ = client.ManagementLocksClient.GetByScope(ctx, clusterId, "lock", nil)
+	if ids := tracker.Ids(); ids != (clients.OperationIds{}) {
+		t.Fatalf("expected the management lock request not to be recorded, got %+v", ids)
+	}
+
+	_, _ = client.OpenShiftClustersClient.Get(ctx, "test-rg", "test-cluster")
+	if ids := tracker.Ids(); ids.RequestId == "" {
+		t.Fatalf("expected the cluster request to be recorded, got %+v", ids)
+	}
+}
@@ -0,0 +1,15 @@
+package clients
+
+// The unexported parts of the recording transport tested by recording_test.go, which is in package clients_test to use
+// the fake without an import cycle.
+const (
+	RecordingModeEnv    = recordingModeEnv
+	RecordingPathEnv    = recordingPathEnv
+	RecordingModeRecord = recordingModeRecord
+	RecordingModeReplay = recordingModeReplay
+)
+
+var (
+	RecordingConfigFromEnv = recordingConfigFromEnv
+	ScrubURL               = scrubURL
+)
@@ -0,0 +1,151 @@
+package clients
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+)
+
+const redactedValue = "REDACTED"
+
+// redactedFields are the JSON properties whose values are masked before a payload is logged.  They are matched case
+// insensitively at any depth of the payload.
+var redactedFields = []string{
+	"pullSecret",
+	"clientSecret",
+	"kubeadminPassword",
+	"kubeconfig",
+}
+
+// correlationHeaders are the response headers Microsoft support asks for when investigating a failed request.
+var correlationHeaders = []string{
+	"x-ms-request-id",
+	"x-ms-correlation-request-id",
+	"x-ms-client-request-id",
+	"Azure-AsyncOperation",
+}
+
+// loggingPolicy logs every request made by the ARO clients along with its response when TF_LOG is set to DEBUG or
+// TRACE, masking any secrets in the payloads.
+type loggingPolicy struct{}
+
+func newLoggingPolicy() policy.Policy {
+	return &loggingPolicy{}
+}
+
+func (p *loggingPolicy) Do(req *policy.Request) (*http.Response, error) {
+	if !logging.IsDebugOrHigher() {
+		return req.Next()
+	}
+
+	raw := req.Raw()
+	log.Printf("[DEBUG] ARO Request: %s %s\n%s", raw.Method, raw.URL.Redacted(), redactPayload(readRequestBody(req)))
+
+	resp, err := req.Next()
+	if err != nil {
+		log.Printf("[DEBUG] ARO Request %s %s failed: %s", raw.Method, raw.URL.Redacted(), err)
+		return resp, err
+	}
+
+	body, err := runtime.Payload(resp)
+	if err != nil {
+		log.Printf("[DEBUG] ARO Response: %s %s: reading body: %s", raw.Method, raw.URL.Redacted(), err)
+		return resp, nil
+	}
+
+	log.Printf("[DEBUG] ARO Response: %s %s: %s%s\n%s", raw.Method, raw.URL.Redacted(), resp.Status, correlationIds(resp.Header), redactPayload(body))
+
+	return resp, nil
+}
+
+func readRequestBody(req *policy.Request) []byte {
+	if req.Body() == nil {
+		return nil
+	}
+
+	body, err := io.ReadAll(req.Body())
+	if err != nil {
+		return nil
+	}
+
+	if err := req.RewindBody(); err != nil {
+		log.Printf("[DEBUG] rewinding request body after logging: %s", err)
+	}
+
+	return body
+}
+
+func correlationIds(header http.Header) string {
+	var ids []string
+	for _, name := range correlationHeaders {
+		if v := header.Get(name); v != "" {
+			ids = append(ids, fmt.Sprintf("%s=%s", name, v))
+		}
+	}
+
+	if len(ids) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(" (%s)", strings.Join(ids, ", "))
+}
+
+// redactPayload returns the JSON payload with the values of the redactedFields masked.  Payloads which aren't JSON
+// are never logged verbatim since they can't be inspected for secrets.
+func redactPayload(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var payload interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return fmt.Sprintf("<non-JSON body of %d bytes>", len(body))
+	}
+
+	redacted, err := json.Marshal(redactValue(payload))
+	if err != nil {
+		return fmt.Sprintf("<unloggable body of %d bytes>", len(body))
+	}
+
+	return string(redacted)
+}
+
+func redactValue(input interface{}) interface{} {
+	switch v := input.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if isRedactedField(key) {
+				if value != nil {
+					v[key] = redactedValue
+				}
+				continue
+			}
+			v[key] = redactValue(value)
+		}
+		return v
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactValue(value)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func isRedactedField(key string) bool {
+	for _, field := range redactedFields {
+		if strings.EqualFold(key, field) {
+			return true
+		}
+	}
+
+	return false
+}
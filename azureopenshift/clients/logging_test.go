@@ -0,0 +1,176 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/streaming"
+)
+
+type recordedTransport struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+func (t *recordedTransport) Do(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		Request:    req,
+		StatusCode: t.statusCode,
+		Status:     http.StatusText(t.statusCode),
+		Header:     t.header,
+		Body:       io.NopCloser(bytes.NewReader(t.body)),
+	}, nil
+}
+
+func readTestData(t *testing.T, name string) []byte {
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("reading %q: %+v", name, err)
+	}
+	return body
+}
+
+func TestRedactPayload(t *testing.T) {
+	testCases := []struct {
+		name     string
+		secrets  []string
+		expected []string
+	}{
+		{
+			name:     "create_cluster_request.json",
+			secrets:  []string{"sp-s3cr3t-value", "c2VjcmV0", "user@example.com"},
+			expected: []string{`"pullSecret":"REDACTED"`, `"clientSecret":"REDACTED"`, `"clientId":"11111111-1111-1111-1111-111111111111"`},
+		},
+		{
+			name:     "list_credentials_response.json",
+			secrets:  []string{"kubeadmin-s3cr3t-value"},
+			expected: []string{`"kubeadminPassword":"REDACTED"`, `"kubeadminUsername":"kubeadmin"`},
+		},
+		{
+			name:     "list_admin_credentials_response.json",
+			secrets:  []string{"YXBpVmVyc2lvbjog"},
+			expected: []string{`"kubeconfig":"REDACTED"`},
+		},
+		{
+			name:     "create_cluster_error_response.json",
+			expected: []string{`"code":"InvalidServicePrincipalCredentials"`},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result := redactPayload(readTestData(t, test.name))
+
+			for _, secret := range test.secrets {
+				if strings.Contains(result, secret) {
+					t.Errorf("Expected %q to be redacted from %s", secret, result)
+				}
+			}
+
+			for _, expected := range test.expected {
+				if !strings.Contains(result, expected) {
+					t.Errorf("Expected %q in %s", expected, result)
+				}
+			}
+		})
+	}
+}
+
+func TestRedactPayload_NonJSON(t *testing.T) {
+	result := redactPayload([]byte("pullSecret=s3cr3t"))
+	if strings.Contains(result, "s3cr3t") {
+		t.Fatalf("Expected non-JSON payloads not to be logged, got %q", result)
+	}
+}
+
+func TestLoggingPolicy(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		logLevel string
+		logged   bool
+	}{
+		{"Nothing is logged without TF_LOG", "", false},
+		{"Nothing is logged at INFO", "INFO", false},
+		{"Requests are logged at DEBUG", "DEBUG", true},
+		{"Requests are logged at TRACE", "TRACE", true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Setenv("TF_LOG", test.logLevel)
+
+			var output bytes.Buffer
+			log.SetOutput(&output)
+			defer log.SetOutput(os.Stderr)
+
+			transport := &recordedTransport{
+				statusCode: http.StatusCreated,
+				header: http.Header{
+					"X-Ms-Request-Id":             []string{"22222222-2222-2222-2222-222222222222"},
+					"X-Ms-Correlation-Request-Id": []string{"33333333-3333-3333-3333-333333333333"},
+					"Azure-Asyncoperation":        []string{"https://management.azure.com/providers/Microsoft.RedHatOpenShift/locations/eastus/operationsstatus/op"},
+				},
+				body: readTestData(t, "list_credentials_response.json"),
+			}
+			pl := runtime.NewPipeline("test", "v0.0.0", runtime.PipelineOptions{}, &policy.ClientOptions{
+				Transport:        transport,
+				PerRetryPolicies: []policy.Policy{newLoggingPolicy()},
+			})
+
+			req, err := runtime.NewRequest(context.Background(), http.MethodPut, "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.RedHatOpenShift/openShiftClusters/tf-openshift")
+			if err != nil {
+				t.Fatalf("building request: %+v", err)
+			}
+			if err := req.SetBody(streaming.NopCloser(bytes.NewReader(readTestData(t, "create_cluster_request.json"))), "application/json"); err != nil {
+				t.Fatalf("setting body: %+v", err)
+			}
+
+			resp, err := pl.Do(req)
+			if err != nil {
+				t.Fatalf("sending request: %+v", err)
+			}
+
+			// the response body must still be readable by the SDK after it was logged
+			body, err := runtime.Payload(resp)
+			if err != nil || !strings.Contains(string(body), "kubeadmin-s3cr3t-value") {
+				t.Fatalf("Expected the response body to be passed through unchanged, got %q (%v)", body, err)
+			}
+
+			logged := output.String()
+			if !test.logged {
+				if logged != "" {
+					t.Fatalf("Expected nothing to be logged, got %q", logged)
+				}
+				return
+			}
+
+			for _, secret := range []string{"sp-s3cr3t-value", "c2VjcmV0", "kubeadmin-s3cr3t-value"} {
+				if strings.Contains(logged, secret) {
+					t.Errorf("Expected %q to be redacted from the log output %q", secret, logged)
+				}
+			}
+
+			for _, expected := range []string{
+				"PUT https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.RedHatOpenShift/openShiftClusters/tf-openshift",
+				"x-ms-request-id=22222222-2222-2222-2222-222222222222",
+				"x-ms-correlation-request-id=33333333-3333-3333-3333-333333333333",
+				"Azure-AsyncOperation=https://management.azure.com/providers/Microsoft.RedHatOpenShift/locations/eastus/operationsstatus/op",
+				`"servicePrincipalProfile":{"clientId":"11111111-1111-1111-1111-111111111111","clientSecret":"REDACTED"}`,
+			} {
+				if !strings.Contains(logged, expected) {
+					t.Errorf("Expected %q in the log output %q", expected, logged)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package clients
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armlocks"
+)
+
+var _ ManagementLocksClient = &armlocks.ManagementLocksClient{}
+
+// ManagementLocksClient manages the management locks of a scope, such as the cluster's `lock_level`.  It's the subset
+// of the SDK's ManagementLocksClient used by the provider, which implements it as is.
+type ManagementLocksClient interface {
+	CreateOrUpdateByScope(ctx context.Context, scope string, lockName string, parameters armlocks.ManagementLockObject, options *armlocks.ManagementLocksClientCreateOrUpdateByScopeOptions) (armlocks.ManagementLocksClientCreateOrUpdateByScopeResponse, error)
+	DeleteByScope(ctx context.Context, scope string, lockName string, options *armlocks.ManagementLocksClientDeleteByScopeOptions) (armlocks.ManagementLocksClientDeleteByScopeResponse, error)
+	GetByScope(ctx context.Context, scope string, lockName string, options *armlocks.ManagementLocksClientGetByScopeOptions) (armlocks.ManagementLocksClientGetByScopeResponse, error)
+}
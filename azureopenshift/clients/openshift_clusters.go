@@ -0,0 +1,72 @@
+package clients
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	redhatopenshift "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/redhatopenshift/armredhatopenshift"
+)
+
+// Poller waits for a long running operation, such as the creation of a cluster, to complete.  It's implemented by the
+// SDK's *runtime.Poller.
+type Poller[T any] interface {
+	PollUntilDone(ctx context.Context, options *runtime.PollUntilDoneOptions) (T, error)
+}
+
+// OpenShiftClustersClient manages Red Hat OpenShift clusters.  It's the subset of the SDK's OpenShiftClustersClient used
+// by the provider, so that the resource can be tested against an in-memory fake.
+type OpenShiftClustersClient interface {
+	Get(ctx context.Context, resourceGroupName string, resourceName string) (redhatopenshift.OpenShiftClustersClientGetResponse, error)
+	BeginCreateOrUpdate(ctx context.Context, resourceGroupName string, resourceName string, parameters redhatopenshift.OpenShiftCluster) (Poller[redhatopenshift.OpenShiftClustersClientCreateOrUpdateResponse], error)
+	BeginUpdate(ctx context.Context, resourceGroupName string, resourceName string, parameters redhatopenshift.OpenShiftClusterUpdate) (Poller[redhatopenshift.OpenShiftClustersClientUpdateResponse], error)
+	BeginDelete(ctx context.Context, resourceGroupName string, resourceName string) (Poller[redhatopenshift.OpenShiftClustersClientDeleteResponse], error)
+	NewListPager() *runtime.Pager[redhatopenshift.OpenShiftClustersClientListResponse]
+	NewListByResourceGroupPager(resourceGroupName string) *runtime.Pager[redhatopenshift.OpenShiftClustersClientListByResourceGroupResponse]
+}
+
+// OpenShiftCredentialsClient retrieves the credentials of Red Hat OpenShift clusters.
+type OpenShiftCredentialsClient interface {
+	ListCredentials(ctx context.Context, resourceGroupName string, resourceName string) (redhatopenshift.OpenShiftClustersClientListCredentialsResponse, error)
+	ListAdminCredentials(ctx context.Context, resourceGroupName string, resourceName string) (redhatopenshift.OpenShiftClustersClientListAdminCredentialsResponse, error)
+}
+
+// sdkOpenShiftClustersClient implements OpenShiftClustersClient and OpenShiftCredentialsClient with the SDK's client.
+type sdkOpenShiftClustersClient struct {
+	client *redhatopenshift.OpenShiftClustersClient
+}
+
+func newSDKOpenShiftClustersClient(client *redhatopenshift.OpenShiftClustersClient) *sdkOpenShiftClustersClient {
+	return &sdkOpenShiftClustersClient{client: client}
+}
+
+func (c *sdkOpenShiftClustersClient) Get(ctx context.Context, resourceGroupName string, resourceName string) (redhatopenshift.OpenShiftClustersClientGetResponse, error) {
+	return c.client.Get(ctx, resourceGroupName, resourceName, nil)
+}
+
+func (c *sdkOpenShiftClustersClient) BeginCreateOrUpdate(ctx context.Context, resourceGroupName string, resourceName string, parameters redhatopenshift.OpenShiftCluster) (Poller[redhatopenshift.OpenShiftClustersClientCreateOrUpdateResponse], error) {
+	return c.client.BeginCreateOrUpdate(ctx, resourceGroupName, resourceName, parameters, nil)
+}
+
+func (c *sdkOpenShiftClustersClient) BeginUpdate(ctx context.Context, resourceGroupName string, resourceName string, parameters redhatopenshift.OpenShiftClusterUpdate) (Poller[redhatopenshift.OpenShiftClustersClientUpdateResponse], error) {
+	return c.client.BeginUpdate(ctx, resourceGroupName, resourceName, parameters, nil)
+}
+
+func (c *sdkOpenShiftClustersClient) BeginDelete(ctx context.Context, resourceGroupName string, resourceName string) (Poller[redhatopenshift.OpenShiftClustersClientDeleteResponse], error) {
+	return c.client.BeginDelete(ctx, resourceGroupName, resourceName, nil)
+}
+
+func (c *sdkOpenShiftClustersClient) NewListPager() *runtime.Pager[redhatopenshift.OpenShiftClustersClientListResponse] {
+	return c.client.NewListPager(nil)
+}
+
+func (c *sdkOpenShiftClustersClient) NewListByResourceGroupPager(resourceGroupName string) *runtime.Pager[redhatopenshift.OpenShiftClustersClientListByResourceGroupResponse] {
+	return c.client.NewListByResourceGroupPager(resourceGroupName, nil)
+}
+
+func (c *sdkOpenShiftClustersClient) ListCredentials(ctx context.Context, resourceGroupName string, resourceName string) (redhatopenshift.OpenShiftClustersClientListCredentialsResponse, error) {
+	return c.client.ListCredentials(ctx, resourceGroupName, resourceName, nil)
+}
+
+func (c *sdkOpenShiftClustersClient) ListAdminCredentials(ctx context.Context, resourceGroupName string, resourceName string) (redhatopenshift.OpenShiftClustersClientListAdminCredentialsResponse, error) {
+	return c.client.ListAdminCredentials(ctx, resourceGroupName, resourceName, nil)
+}
@@ -0,0 +1,108 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// OperationIds are the identifiers of an ARM operation which Microsoft support asks for when investigating a failure.
+type OperationIds struct {
+	RequestId            string
+	CorrelationRequestId string
+	AsyncOperationUrl    string
+}
+
+func (ids OperationIds) String() string {
+	var parts []string
+	if ids.RequestId != "" {
+		parts = append(parts, fmt.Sprintf("x-ms-request-id: %s", ids.RequestId))
+	}
+	if ids.CorrelationRequestId != "" {
+		parts = append(parts, fmt.Sprintf("x-ms-correlation-request-id: %s", ids.CorrelationRequestId))
+	}
+	if ids.AsyncOperationUrl != "" {
+		parts = append(parts, fmt.Sprintf("Azure-AsyncOperation: %s", ids.AsyncOperationUrl))
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("Operation identifiers:\n\t%s", strings.Join(parts, "\n\t"))
+}
+
+// OperationTracker records the identifiers of the requests made for an operation.
+type OperationTracker struct {
+	mu  sync.Mutex
+	ids OperationIds
+}
+
+type operationTrackerKey struct{}
+
+// WithOperationTracker returns a context which records the identifiers of every ARO request made with it, including
+// the long running operation polling requests, into the returned OperationTracker.
+func WithOperationTracker(ctx context.Context) (context.Context, *OperationTracker) {
+	tracker := &OperationTracker{}
+	return context.WithValue(ctx, operationTrackerKey{}, tracker), tracker
+}
+
+// Ids returns the identifiers recorded so far.
+func (t *OperationTracker) Ids() OperationIds {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.ids
+}
+
+// Wrap annotates err with the identifiers recorded so far.
+func (t *OperationTracker) Wrap(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if s := t.Ids().String(); s != "" {
+		return fmt.Errorf("%w\n\n%s", err, s)
+	}
+
+	return err
+}
+
+func (t *OperationTracker) record(header http.Header) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if v := header.Get("x-ms-request-id"); v != "" {
+		t.ids.RequestId = v
+	}
+	if v := header.Get("x-ms-correlation-request-id"); v != "" {
+		t.ids.CorrelationRequestId = v
+	}
+
+	// only the initial response of a long running operation carries the operation URL, keep it for the polling
+	if v := header.Get("Azure-AsyncOperation"); v != "" {
+		t.ids.AsyncOperationUrl = v
+	}
+}
+
+// operationIdsPolicy records the identifiers of every response into the OperationTracker stored in the request
+// context.
+type operationIdsPolicy struct{}
+
+func newOperationIdsPolicy() policy.Policy {
+	return &operationIdsPolicy{}
+}
+
+func (p *operationIdsPolicy) Do(req *policy.Request) (*http.Response, error) {
+	resp, err := req.Next()
+
+	if tracker, ok := req.Raw().Context().Value(operationTrackerKey{}).(*OperationTracker); ok && resp != nil {
+		tracker.record(resp.Header)
+	}
+
+	return resp, err
+}
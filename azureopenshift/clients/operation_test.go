@@ -0,0 +1,75 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+func TestOperationTracker(t *testing.T) {
+	transport := &recordedTransport{statusCode: http.StatusOK}
+	pl := runtime.NewPipeline("test", "v0.0.0", runtime.PipelineOptions{}, &policy.ClientOptions{
+		Transport:        transport,
+		PerRetryPolicies: []policy.Policy{newOperationIdsPolicy()},
+	})
+
+	ctx, tracker := WithOperationTracker(context.Background())
+
+	responses := []http.Header{
+		// the initial PUT of the long running operation
+		{
+			"X-Ms-Request-Id":             []string{"request-1"},
+			"X-Ms-Correlation-Request-Id": []string{"correlation-1"},
+			"Azure-Asyncoperation":        []string{"https://management.azure.com/operationsstatus/op"},
+		},
+		// a polling request which doesn't return the operation URL again
+		{
+			"X-Ms-Request-Id":             []string{"request-2"},
+			"X-Ms-Correlation-Request-Id": []string{"correlation-2"},
+		},
+	}
+
+	for _, header := range responses {
+		transport.header = header
+		req, err := runtime.NewRequest(ctx, http.MethodGet, "https://management.azure.com/operationsstatus/op")
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+		if _, err := pl.Do(req); err != nil {
+			t.Fatalf("sending request: %+v", err)
+		}
+	}
+
+	expected := OperationIds{
+		RequestId:            "request-2",
+		CorrelationRequestId: "correlation-2",
+		AsyncOperationUrl:    "https://management.azure.com/operationsstatus/op",
+	}
+	if ids := tracker.Ids(); ids != expected {
+		t.Fatalf("Expected %+v - got %+v", expected, ids)
+	}
+
+	err := tracker.Wrap(errors.New("waiting for creation"))
+	for _, s := range []string{"waiting for creation", "x-ms-request-id: request-2", "x-ms-correlation-request-id: correlation-2", "Azure-AsyncOperation: https://management.azure.com/operationsstatus/op"} {
+		if !strings.Contains(err.Error(), s) {
+			t.Errorf("Expected %q in %q", s, err.Error())
+		}
+	}
+}
+
+func TestOperationTracker_NoRequests(t *testing.T) {
+	_, tracker := WithOperationTracker(context.Background())
+
+	if err := tracker.Wrap(nil); err != nil {
+		t.Fatalf("Expected nil to be passed through - got %+v", err)
+	}
+
+	if err := tracker.Wrap(errors.New("failed")); err.Error() != "failed" {
+		t.Fatalf("Expected the error to be unchanged - got %q", err.Error())
+	}
+}
@@ -0,0 +1,346 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// The environment variables switching the ARM clients to recording or replaying their traffic.  They are meant for
+// tests: ARM_RECORDING_MODE is either `record`, which sends the requests to Azure and writes the interactions to the
+// fixture at ARM_RECORDING_PATH, or `replay`, which serves the requests from that fixture without any network access or
+// credentials.
+const (
+	recordingModeEnv = "ARM_RECORDING_MODE"
+	recordingPathEnv = "ARM_RECORDING_PATH"
+
+	recordingModeRecord = "record"
+	recordingModeReplay = "replay"
+)
+
+// scrubbedSubscriptionId replaces every subscription ID written to a fixture.
+const scrubbedSubscriptionId = "00000000-0000-0000-0000-000000000000"
+
+var subscriptionIdPattern = regexp.MustCompile(`(?i)(/subscriptions/)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+
+// recordedHeaders are the response headers kept in fixtures, those driving long running operations and errors.
+var recordedHeaders = []string{
+	"Content-Type",
+	"Azure-AsyncOperation",
+	"Location",
+	"Retry-After",
+	"x-ms-error-code",
+}
+
+type fixture struct {
+	Interactions []interaction `json:"interactions"`
+}
+
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+type recordedRequest struct {
+	Method string          `json:"method"`
+	URL    string          `json:"url"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+type recordedResponse struct {
+	StatusCode int               `json:"status_code"`
+	Header     map[string]string `json:"header,omitempty"`
+	Body       json.RawMessage   `json:"body,omitempty"`
+}
+
+func (i interaction) key() string {
+	return fmt.Sprintf("%s %s", i.Request.Method, i.Request.URL)
+}
+
+// newReplayClientOptions returns the credential and client options of clients replaying the fixture of the config.
+func newReplayClientOptions(config recordingConfig, perCallPolicies ...policy.Policy) (azcore.TokenCredential, policy.ClientOptions, error) {
+	transport, err := newReplayTransport(config.path, config.subscriptionId)
+	if err != nil {
+		return nil, policy.ClientOptions{}, err
+	}
+
+	return replayCredential{}, policy.ClientOptions{
+		Transport:       transport,
+		PerCallPolicies: perCallPolicies,
+		Retry: policy.RetryOptions{
+			RetryDelay:    time.Millisecond,
+			MaxRetryDelay: time.Millisecond,
+		},
+	}, nil
+}
+
+type recordingConfig struct {
+	mode           string
+	path           string
+	subscriptionId string
+}
+
+func recordingConfigFromEnv(subscriptionId string) (recordingConfig, error) {
+	config := recordingConfig{
+		mode:           strings.ToLower(os.Getenv(recordingModeEnv)),
+		path:           os.Getenv(recordingPathEnv),
+		subscriptionId: subscriptionId,
+	}
+
+	switch config.mode {
+	case "":
+		return config, nil
+	case recordingModeRecord, recordingModeReplay:
+		if config.path == "" {
+			return config, fmt.Errorf("%s must be set when %s is %q", recordingPathEnv, recordingModeEnv, config.mode)
+		}
+		return config, nil
+	}
+
+	return config, fmt.Errorf("%s must be one of %q or %q, got %q", recordingModeEnv, recordingModeRecord, recordingModeReplay, config.mode)
+}
+
+// recordingTransport sends the requests to the next transport and appends every interaction, scrubbed of subscription
+// IDs and secrets, to the fixture.  Consecutive identical responses to a GET, such as the polls of a long running
+// operation still in progress, are only recorded once.
+type recordingTransport struct {
+	next           policy.Transporter
+	path           string
+	subscriptionId string
+
+	mu      sync.Mutex
+	fixture fixture
+}
+
+// newRecordingTransport records the traffic sent through next.  Only the ARM clients use it, the token requests of the
+// credential are never recorded.
+func newRecordingTransport(config recordingConfig, next policy.Transporter) *recordingTransport {
+	return &recordingTransport{
+		next:           next,
+		path:           config.path,
+		subscriptionId: config.subscriptionId,
+	}
+}
+
+func (t *recordingTransport) Do(req *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("reading the request body to record: %w", err)
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		requestBody = body
+	}
+
+	resp, err := t.next.Do(req)
+	if err != nil {
+		return resp, err
+	}
+
+	responseBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("reading the response body to record: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	recorded := interaction{
+		Request: recordedRequest{
+			Method: req.Method,
+			URL:    scrubURL(req.URL, t.subscriptionId),
+			Body:   scrubBody(requestBody, t.subscriptionId),
+		},
+		Response: recordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     scrubHeader(resp.Header, t.subscriptionId),
+			Body:       scrubBody(responseBody, t.subscriptionId),
+		},
+	}
+
+	if err := t.record(recorded); err != nil {
+		return nil, fmt.Errorf("recording %s %s to %q: %w", req.Method, recorded.Request.URL, t.path, err)
+	}
+
+	return resp, nil
+}
+
+func (t *recordingTransport) record(recorded interaction) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if recorded.Request.Method == http.MethodGet {
+		for i := len(t.fixture.Interactions) - 1; i >= 0; i-- {
+			previous := t.fixture.Interactions[i]
+			if previous.key() != recorded.key() {
+				continue
+			}
+			if sameResponse(previous.Response, recorded.Response) {
+				return nil
+			}
+			break
+		}
+	}
+
+	t.fixture.Interactions = append(t.fixture.Interactions, recorded)
+
+	data, err := json.MarshalIndent(t.fixture, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(t.path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(t.path, data, 0o600)
+}
+
+func sameResponse(a, b recordedResponse) bool {
+	return a.StatusCode == b.StatusCode && fmt.Sprint(a.Header) == fmt.Sprint(b.Header) && bytes.Equal(a.Body, b.Body)
+}
+
+// replayTransport serves the requests from a fixture.  Requests are matched on their method and URL, and the
+// interactions recorded for the same request are served in order, the last one of a GET being repeated once the others
+// are exhausted.  The requests never reach the network.
+type replayTransport struct {
+	subscriptionId string
+
+	mu           sync.Mutex
+	interactions map[string][]interaction
+}
+
+func newReplayTransport(path string, subscriptionId string) (*replayTransport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading fixture: %w", err)
+	}
+
+	var recorded fixture
+	if err := json.Unmarshal(data, &recorded); err != nil {
+		return nil, fmt.Errorf("parsing fixture %q: %w", path, err)
+	}
+
+	t := &replayTransport{
+		subscriptionId: subscriptionId,
+		interactions:   make(map[string][]interaction),
+	}
+	for _, i := range recorded.Interactions {
+		t.interactions[i.key()] = append(t.interactions[i.key()], i)
+	}
+
+	return t, nil
+}
+
+func (t *replayTransport) Do(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	key := fmt.Sprintf("%s %s", req.Method, scrubURL(req.URL, t.subscriptionId))
+	queue := t.interactions[key]
+	if len(queue) == 0 {
+		return nil, fmt.Errorf("no recorded interaction left for %s", key)
+	}
+
+	replayed := queue[0]
+	if len(queue) > 1 || req.Method != http.MethodGet {
+		t.interactions[key] = queue[1:]
+	}
+
+	resp := &http.Response{
+		Request:    req,
+		StatusCode: replayed.Response.StatusCode,
+		Status:     fmt.Sprintf("%d %s", replayed.Response.StatusCode, http.StatusText(replayed.Response.StatusCode)),
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(bytes.NewReader(replayed.Response.Body)),
+	}
+	for name, value := range replayed.Response.Header {
+		resp.Header.Set(name, value)
+	}
+
+	// long running operations are polled every 30 seconds unless told otherwise, which replays don't need to wait for
+	if resp.Header.Get("Retry-After") != "" || resp.Header.Get("Azure-AsyncOperation") != "" || resp.Header.Get("Location") != "" {
+		resp.Header.Set("Retry-After", "1")
+	}
+
+	return resp, nil
+}
+
+// replayCredential is the credential of replayed clients, whose requests never leave the process.
+type replayCredential struct{}
+
+func (replayCredential) GetToken(_ context.Context, _ policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{Token: redactedValue, ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+// scrubURL returns the path and query of the URL with its subscription ID scrubbed.  The host isn't kept so that
+// fixtures replay whichever endpoint they were recorded against.
+func scrubURL(u *url.URL, subscriptionId string) string {
+	scrubbed := scrubSubscriptionIds(u.EscapedPath(), subscriptionId)
+	if query := u.Query().Encode(); query != "" {
+		scrubbed += "?" + query
+	}
+
+	return scrubbed
+}
+
+func scrubHeader(header http.Header, subscriptionId string) map[string]string {
+	scrubbed := make(map[string]string)
+	for _, name := range recordedHeaders {
+		if v := header.Get(name); v != "" {
+			scrubbed[name] = scrubSubscriptionIds(v, subscriptionId)
+		}
+	}
+
+	return scrubbed
+}
+
+// scrubBody returns the JSON body with the values of the redactedFields masked and its subscription IDs scrubbed.
+// Bodies which aren't JSON are never recorded since they can't be inspected for secrets.
+func scrubBody(body []byte, subscriptionId string) json.RawMessage {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	var payload interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil
+	}
+
+	scrubbed, err := json.Marshal(redactValue(payload))
+	if err != nil {
+		return nil
+	}
+
+	return json.RawMessage(scrubSubscriptionIds(string(scrubbed), subscriptionId))
+}
+
+func scrubSubscriptionIds(input string, subscriptionId string) string {
+	scrubbed := subscriptionIdPattern.ReplaceAllString(input, "${1}"+scrubbedSubscriptionId)
+	if subscriptionId != "" {
+		scrubbed = strings.ReplaceAll(scrubbed, subscriptionId, scrubbedSubscriptionId)
+	}
+
+	return scrubbed
+}
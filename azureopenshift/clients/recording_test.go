@@ -0,0 +1,154 @@
+package clients_test
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	redhatopenshift "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/redhatopenshift/armredhatopenshift"
+
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/auth"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/clients"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/fake"
+)
+
+// runClusterLifecycle creates a cluster, retrieves its credentials and deletes it.
+func runClusterLifecycle(t *testing.T, client *clients.Client) {
+	t.Helper()
+	ctx := context.Background()
+
+	poller, err := client.OpenShiftClustersClient.BeginCreateOrUpdate(ctx, "test-rg", "test-cluster", redhatopenshift.OpenShiftCluster{
+		Location: to.Ptr("eastus"),
+		Properties: &redhatopenshift.OpenShiftClusterProperties{
+			ClusterProfile: &redhatopenshift.ClusterProfile{
+				Domain:     to.Ptr("abcdefgh"),
+				PullSecret: to.Ptr(`{"auths":{"registry.example.com":{"auth":"cHVsbC1zM2NyM3Q="}}}`),
+			},
+			ServicePrincipalProfile: &redhatopenshift.ServicePrincipalProfile{
+				ClientID:     to.Ptr(fake.ClientID),
+				ClientSecret: to.Ptr("sp-s3cr3t-value"),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("creating cluster: %+v", err)
+	}
+	created, err := poller.PollUntilDone(ctx, nil)
+	if err != nil {
+		t.Fatalf("waiting for the cluster creation: %+v", err)
+	}
+	if state := *created.Properties.ProvisioningState; state != redhatopenshift.ProvisioningStateSucceeded {
+		t.Fatalf("expected the cluster to be Succeeded, got %s", state)
+	}
+
+	creds, err := client.OpenShiftCredentialsClient.ListCredentials(ctx, "test-rg", "test-cluster")
+	if err != nil {
+		t.Fatalf("listing credentials: %+v", err)
+	}
+	if *creds.KubeadminUsername != fake.KubeadminUsername {
+		t.Fatalf("unexpected kubeadmin username %q", *creds.KubeadminUsername)
+	}
+
+	if _, err := client.OpenShiftCredentialsClient.ListAdminCredentials(ctx, "test-rg", "test-cluster"); err != nil {
+		t.Fatalf("listing admin credentials: %+v", err)
+	}
+
+	deletePoller, err := client.OpenShiftClustersClient.BeginDelete(ctx, "test-rg", "test-cluster")
+	if err != nil {
+		t.Fatalf("deleting cluster: %+v", err)
+	}
+	if _, err := deletePoller.PollUntilDone(ctx, nil); err != nil {
+		t.Fatalf("waiting for the cluster deletion: %+v", err)
+	}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	fixturePath := filepath.Join(t.TempDir(), "fixtures", "cluster_lifecycle.json")
+
+	server := fake.NewServer(fake.Options{OperationDelay: 1500 * time.Millisecond})
+	caBundlePath := filepath.Join(t.TempDir(), "ca.pem")
+	if err := server.WriteCABundle(caBundlePath); err != nil {
+		t.Fatalf("writing CA bundle: %+v", err)
+	}
+
+	t.Setenv(clients.RecordingModeEnv, clients.RecordingModeRecord)
+	t.Setenv(clients.RecordingPathEnv, fixturePath)
+
+	client, diags := clients.NewClient(context.Background(), context.Background(), server.AuthConfig(caBundlePath))
+	if diags.HasError() {
+		t.Fatalf("building recording client: %+v", diags)
+	}
+	runClusterLifecycle(t, client)
+	server.Close()
+
+	data, err := os.ReadFile(fixturePath)
+	if err != nil {
+		t.Fatalf("reading fixture: %+v", err)
+	}
+	fixture := string(data)
+
+	for _, secret := range []string{"sp-s3cr3t-value", "cHVsbC1zM2NyM3Q=", fake.KubeadminPassword, fake.ClientSecret, fake.AccessToken} {
+		if strings.Contains(fixture, secret) {
+			t.Errorf("expected %q to be scrubbed from the fixture", secret)
+		}
+	}
+	if !strings.Contains(fixture, `"kubeconfig": "REDACTED"`) {
+		t.Errorf("expected the kubeconfig to be redacted")
+	}
+	if strings.Count(fixture, `"status": "InProgress"`) > 2 {
+		t.Errorf("expected the polls of an operation in progress to be recorded once, got:\n%s", fixture)
+	}
+
+	// replay against another subscription, without credentials nor the fake
+	t.Setenv(clients.RecordingModeEnv, clients.RecordingModeReplay)
+	replayed, diags := clients.NewClient(context.Background(), context.Background(), auth.Config{SubscriptionId: "12345678-9abc-def0-1234-56789abcdef0"})
+	if diags.HasError() {
+		t.Fatalf("building replaying client: %+v", diags)
+	}
+	runClusterLifecycle(t, replayed)
+
+	if _, err := replayed.OpenShiftCredentialsClient.ListCredentials(context.Background(), "test-rg", "unrecorded"); err == nil || !strings.Contains(err.Error(), "no recorded interaction") {
+		t.Fatalf("expected unrecorded requests to fail, got %+v", err)
+	}
+}
+
+func TestRecordingConfigFromEnv(t *testing.T) {
+	testCases := []struct {
+		mode     string
+		path     string
+		hasError bool
+	}{
+		{mode: "", path: "", hasError: false},
+		{mode: "record", path: "fixture.json", hasError: false},
+		{mode: "REPLAY", path: "fixture.json", hasError: false},
+		{mode: "replay", path: "", hasError: true},
+		{mode: "rewind", path: "fixture.json", hasError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Setenv(clients.RecordingModeEnv, tc.mode)
+		t.Setenv(clients.RecordingPathEnv, tc.path)
+
+		_, err := clients.RecordingConfigFromEnv("")
+		if (err != nil) != tc.hasError {
+			t.Errorf("mode %q and path %q: expected an error %t, got %+v", tc.mode, tc.path, tc.hasError, err)
+		}
+	}
+}
+
+func TestScrubURL(t *testing.T) {
+	u, err := url.Parse("https://management.azure.com/subscriptions/12345678-9ABC-def0-1234-56789abcdef0/resourceGroups/rg?api-version=2023-09-04&$filter=a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg?%24filter=a&api-version=2023-09-04"
+	if scrubbed := clients.ScrubURL(u, ""); scrubbed != expected {
+		t.Fatalf("expected %q, got %q", expected, scrubbed)
+	}
+}
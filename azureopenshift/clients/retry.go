@@ -0,0 +1,66 @@
+package clients
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/utils"
+)
+
+const (
+	retryInitialDelay = 10 * time.Second
+	retryMaxDelay     = 2 * time.Minute
+)
+
+// RetryOptions control how transient failures of the ARO API are retried, on top of the retries made for each
+// individual request by the SDK pipeline.
+type RetryOptions struct {
+	// Attempts is the maximum number of attempts of an operation, 1 disables retries.
+	Attempts int
+	// MaxBackoff is the maximum total time spent waiting between the attempts of an operation.
+	MaxBackoff time.Duration
+}
+
+// Retry calls fn until it succeeds, fails with an error which isn't transient, or the attempts are exhausted.  The
+// delay between attempts honours any `Retry-After` returned by ARO and is otherwise exponential, and no attempt is made
+// when waiting would exceed the total backoff or the deadline of ctx, i.e. the remaining resource timeout.
+func Retry[T any](ctx context.Context, opts RetryOptions, operation string, fn func() (T, error)) (T, error) {
+	var totalBackoff time.Duration
+	delay := retryInitialDelay
+
+	for attempt := 1; ; attempt++ {
+		result, err := fn()
+		if err == nil || attempt >= opts.Attempts || !utils.ResponseErrorIsRetryable(err) {
+			return result, err
+		}
+
+		wait := utils.ResponseRetryAfter(err)
+		if wait == 0 {
+			wait = delay
+			delay *= 2
+			if delay > retryMaxDelay {
+				delay = retryMaxDelay
+			}
+		}
+
+		if totalBackoff+wait > opts.MaxBackoff {
+			log.Printf("[DEBUG] not retrying %s: waiting %s would exceed the maximum backoff of %s", operation, wait, opts.MaxBackoff)
+			return result, err
+		}
+
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			log.Printf("[DEBUG] not retrying %s: waiting %s would exceed the timeout", operation, wait)
+			return result, err
+		}
+
+		log.Printf("[INFO] %s failed with a transient error (attempt %d of %d), retrying in %s: %s", operation, attempt, opts.Attempts, wait, err)
+
+		select {
+		case <-ctx.Done():
+			return result, err
+		case <-time.After(wait):
+		}
+		totalBackoff += wait
+	}
+}
@@ -0,0 +1,117 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+)
+
+func transientError(statusCode int, retryAfterMs string) error {
+	header := http.Header{}
+	if retryAfterMs != "" {
+		header.Set("retry-after-ms", retryAfterMs)
+	}
+
+	return &azcore.ResponseError{
+		StatusCode:  statusCode,
+		RawResponse: &http.Response{StatusCode: statusCode, Header: header},
+	}
+}
+
+func TestRetry(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		opts             RetryOptions
+		timeout          time.Duration
+		errs             []error
+		expectedAttempts int
+		expectedErr      bool
+	}{
+		{
+			desc:             "Successful calls are not retried",
+			opts:             RetryOptions{Attempts: 3, MaxBackoff: time.Minute},
+			errs:             []error{nil},
+			expectedAttempts: 1,
+		},
+		{
+			desc:             "Transient failures are retried",
+			opts:             RetryOptions{Attempts: 3, MaxBackoff: time.Minute},
+			errs:             []error{transientError(http.StatusConflict, "1"), transientError(http.StatusTooManyRequests, "1"), nil},
+			expectedAttempts: 3,
+		},
+		{
+			desc:             "Attempts are limited",
+			opts:             RetryOptions{Attempts: 2, MaxBackoff: time.Minute},
+			errs:             []error{transientError(http.StatusInternalServerError, "1"), transientError(http.StatusInternalServerError, "1"), nil},
+			expectedAttempts: 2,
+			expectedErr:      true,
+		},
+		{
+			desc:             "Other failures are not retried",
+			opts:             RetryOptions{Attempts: 3, MaxBackoff: time.Minute},
+			errs:             []error{transientError(http.StatusBadRequest, "1"), nil},
+			expectedAttempts: 1,
+			expectedErr:      true,
+		},
+		{
+			desc:             "Retries stop at the maximum backoff",
+			opts:             RetryOptions{Attempts: 5, MaxBackoff: 15 * time.Millisecond},
+			errs:             []error{transientError(http.StatusConflict, "10"), transientError(http.StatusConflict, "10"), nil},
+			expectedAttempts: 2,
+			expectedErr:      true,
+		},
+		{
+			desc:             "Retries stop before the timeout",
+			opts:             RetryOptions{Attempts: 5, MaxBackoff: time.Hour},
+			timeout:          time.Second,
+			errs:             []error{transientError(http.StatusConflict, "60000"), nil},
+			expectedAttempts: 1,
+			expectedErr:      true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			if test.timeout != 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, test.timeout)
+				defer cancel()
+			}
+
+			attempts := 0
+			_, err := Retry(ctx, test.opts, "testing", func() (string, error) {
+				err := test.errs[attempts]
+				attempts++
+				return "", err
+			})
+
+			if attempts != test.expectedAttempts {
+				t.Errorf("Expected %d attempts - got %d", test.expectedAttempts, attempts)
+			}
+			if (err != nil) != test.expectedErr {
+				t.Errorf("Expected an error: %t - got %+v", test.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestRetry_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	attempts := 0
+	_, err := Retry(ctx, RetryOptions{Attempts: 5, MaxBackoff: time.Hour}, "testing", func() (string, error) {
+		attempts++
+		return "", transientError(http.StatusConflict, "60000")
+	})
+
+	var responseError *azcore.ResponseError
+	if attempts != 1 || !errors.As(err, &responseError) {
+		t.Fatalf("Expected the last error after a single attempt - got %d attempts and %+v", attempts, err)
+	}
+}
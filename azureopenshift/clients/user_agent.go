@@ -0,0 +1,49 @@
+package clients
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// userAgentPolicy appends the partner ID and any custom suffix to the User-Agent header set by the SDK's telemetry
+// policy, so usage can be attributed both in ARM and in the activity logs.
+type userAgentPolicy struct {
+	suffix string
+}
+
+func newUserAgentPolicy(partnerId string, userAgentSuffix string) policy.Policy {
+	var parts []string
+	if partnerId != "" {
+		if !strings.HasPrefix(partnerId, "pid-") {
+			partnerId = fmt.Sprintf("pid-%s", partnerId)
+		}
+		parts = append(parts, partnerId)
+	}
+
+	if suffix := strings.TrimSpace(userAgentSuffix); suffix != "" {
+		parts = append(parts, suffix)
+	}
+
+	return &userAgentPolicy{
+		suffix: strings.Join(parts, " "),
+	}
+}
+
+func (p *userAgentPolicy) Do(req *policy.Request) (*http.Response, error) {
+	if p.suffix == "" {
+		return req.Next()
+	}
+
+	userAgent := req.Raw().Header.Get("User-Agent")
+	if userAgent == "" {
+		userAgent = p.suffix
+	} else {
+		userAgent = fmt.Sprintf("%s %s", userAgent, p.suffix)
+	}
+	req.Raw().Header.Set("User-Agent", userAgent)
+
+	return req.Next()
+}
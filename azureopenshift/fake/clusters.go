@@ -0,0 +1,517 @@
+package fake
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	redhatopenshift "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/redhatopenshift/armredhatopenshift"
+)
+
+const (
+	clusterResourceType = "Microsoft.RedHatOpenShift/openShiftClusters"
+	defaultVersion      = "4.13.23"
+)
+
+// operation is a long running operation on a cluster.
+type operation struct {
+	id        string
+	clusterId string
+	kind      string
+	location  string
+	start     time.Time
+	end       time.Time
+	failure   *Failure
+	done      bool
+}
+
+func (o *operation) status() string {
+	switch {
+	case !o.done:
+		return "InProgress"
+	case o.failure != nil:
+		return "Failed"
+	default:
+		return "Succeeded"
+	}
+}
+
+// Cluster returns the cluster with the given ID, as returned by the fake.
+func (s *Server) Cluster(id string) (redhatopenshift.OpenShiftCluster, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.completeOperations()
+
+	cluster, ok := s.clusters[strings.ToLower(id)]
+	if !ok {
+		return redhatopenshift.OpenShiftCluster{}, false
+	}
+
+	return redacted(cluster), true
+}
+
+// StoredCluster returns the cluster with the given ID as it was sent to the fake, including the secrets which are never
+// returned.
+func (s *Server) StoredCluster(id string) (redhatopenshift.OpenShiftCluster, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.completeOperations()
+
+	cluster, ok := s.clusters[strings.ToLower(id)]
+	if !ok {
+		return redhatopenshift.OpenShiftCluster{}, false
+	}
+
+	return *copyCluster(cluster), true
+}
+
+// SetCluster stores the cluster as if it was created outside of the provider, along with the read-only properties ARO
+// fills in.  Its ID must be set.
+func (s *Server) SetCluster(cluster redhatopenshift.OpenShiftCluster) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := existingCluster(cluster)
+	s.clusters[strings.ToLower(*stored.ID)] = stored
+}
+
+// RemoveCluster removes the cluster with the given ID, as if it was deleted outside of the provider.
+func (s *Server) RemoveCluster(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.clusters, strings.ToLower(id))
+}
+
+// serveResourceManager routes the requests made to `/subscriptions/...`.
+func (s *Server) serveResourceManager(w http.ResponseWriter, r *http.Request, segments []string) {
+	// /subscriptions/{sub}/providers/Microsoft.RedHatOpenShift/locations/{location}/operationsStatus/{id}
+	if len(segments) == 8 && strings.EqualFold(segments[2], "providers") && strings.EqualFold(segments[6], "operationsStatus") {
+		s.serveOperationStatus(w, r, segments[7])
+		return
+	}
+
+	// /subscriptions/{sub}/resourceGroups/{rg}/providers/Microsoft.RedHatOpenShift/openShiftClusters/{name}/providers/Microsoft.Authorization/locks/{lock}
+	if len(segments) == 12 && strings.EqualFold(segments[8], "providers") && strings.EqualFold(segments[9]+"/"+segments[10], lockResourceType) {
+		s.serveLock(w, r, "/"+strings.Join(segments[:8], "/"), segments[11])
+		return
+	}
+
+	// /subscriptions/{sub}/resourceGroups/{rg}/providers/Microsoft.RedHatOpenShift/openShiftClusters/{name}[/{action}]
+	isCluster := (len(segments) == 8 || len(segments) == 9) &&
+		strings.EqualFold(segments[2], "resourceGroups") &&
+		strings.EqualFold(segments[4], "providers") &&
+		strings.EqualFold(segments[5]+"/"+segments[6], clusterResourceType)
+	if !isCluster {
+		writeError(w, http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("The resource %q was not found.", r.URL.Path))
+		return
+	}
+
+	id := "/" + strings.Join(segments[:8], "/")
+
+	switch {
+	case len(segments) == 8 && r.Method == http.MethodPut:
+		s.putCluster(w, r, id, segments)
+	case len(segments) == 8 && r.Method == http.MethodPatch:
+		s.patchCluster(w, r, id)
+	case len(segments) == 8 && r.Method == http.MethodGet:
+		s.getCluster(w, id)
+	case len(segments) == 8 && r.Method == http.MethodDelete:
+		s.deleteCluster(w, id)
+	case len(segments) == 9 && r.Method == http.MethodPost && strings.EqualFold(segments[8], "listCredentials"):
+		s.listCredentials(w, id)
+	case len(segments) == 9 && r.Method == http.MethodPost && strings.EqualFold(segments[8], "listAdminCredentials"):
+		s.listAdminCredentials(w, id)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", fmt.Sprintf("%s is not supported on %q.", r.Method, r.URL.Path))
+	}
+}
+
+func (s *Server) putCluster(w http.ResponseWriter, r *http.Request, id string, segments []string) {
+	failure := s.failures.take(OperationCreateOrUpdate)
+	if failure != nil && !failure.Async {
+		writeError(w, failure.StatusCode, failure.Code, failure.Message)
+		return
+	}
+
+	var cluster redhatopenshift.OpenShiftCluster
+	if err := json.NewDecoder(r.Body).Decode(&cluster); err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+		return
+	}
+	if cluster.Location == nil || cluster.Properties == nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequestContent", "The `location` and `properties` of the cluster are required.")
+		return
+	}
+
+	existing, exists := s.clusters[strings.ToLower(id)]
+	if exists && isBusy(existing) {
+		writeError(w, http.StatusConflict, "RequestNotAllowed", "Request is not allowed in provisioningState "+string(*existing.Properties.ProvisioningState)+".")
+		return
+	}
+
+	cluster.ID = to.Ptr(id)
+	cluster.Name = to.Ptr(segments[7])
+	cluster.Type = to.Ptr(clusterResourceType)
+	populateDefaults(&cluster)
+
+	state := redhatopenshift.ProvisioningStateCreating
+	kind := OperationCreateOrUpdate
+	status := http.StatusCreated
+	if exists {
+		state = redhatopenshift.ProvisioningStateUpdating
+		status = http.StatusOK
+	}
+	cluster.Properties.ProvisioningState = &state
+	s.clusters[strings.ToLower(id)] = &cluster
+
+	s.startOperation(w, &cluster, kind, failure)
+	writeJSON(w, status, redacted(&cluster))
+}
+
+func (s *Server) patchCluster(w http.ResponseWriter, r *http.Request, id string) {
+	failure := s.failures.take(OperationUpdate)
+	if failure != nil && !failure.Async {
+		writeError(w, failure.StatusCode, failure.Code, failure.Message)
+		return
+	}
+
+	cluster, ok := s.clusters[strings.ToLower(id)]
+	if !ok {
+		writeError(w, http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("The Resource %q was not found.", id))
+		return
+	}
+	if isBusy(cluster) {
+		writeError(w, http.StatusConflict, "RequestNotAllowed", "Request is not allowed in provisioningState "+string(*cluster.Properties.ProvisioningState)+".")
+		return
+	}
+
+	var update redhatopenshift.OpenShiftClusterUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+		return
+	}
+
+	if update.Tags != nil {
+		cluster.Tags = update.Tags
+	}
+	if props := update.Properties; props != nil {
+		patchProperties(cluster, props)
+	}
+
+	state := redhatopenshift.ProvisioningStateUpdating
+	cluster.Properties.ProvisioningState = &state
+
+	s.startOperation(w, cluster, OperationUpdate, failure)
+	writeJSON(w, http.StatusOK, redacted(cluster))
+}
+
+func (s *Server) getCluster(w http.ResponseWriter, id string) {
+	if failure := s.failures.take(OperationGet); failure != nil {
+		writeError(w, failure.StatusCode, failure.Code, failure.Message)
+		return
+	}
+
+	cluster, ok := s.clusters[strings.ToLower(id)]
+	if !ok {
+		writeError(w, http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("The Resource %q was not found.", id))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, redacted(cluster))
+}
+
+func (s *Server) deleteCluster(w http.ResponseWriter, id string) {
+	failure := s.failures.take(OperationDelete)
+	if failure != nil && !failure.Async {
+		writeError(w, failure.StatusCode, failure.Code, failure.Message)
+		return
+	}
+
+	cluster, ok := s.clusters[strings.ToLower(id)]
+	if !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	state := redhatopenshift.ProvisioningStateDeleting
+	cluster.Properties.ProvisioningState = &state
+
+	s.startOperation(w, cluster, OperationDelete, failure)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) listCredentials(w http.ResponseWriter, id string) {
+	if failure := s.failures.take(OperationListCredentials); failure != nil {
+		writeError(w, failure.StatusCode, failure.Code, failure.Message)
+		return
+	}
+
+	if _, ok := s.clusters[strings.ToLower(id)]; !ok {
+		writeError(w, http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("The Resource %q was not found.", id))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, redhatopenshift.OpenShiftClusterCredentials{
+		KubeadminUsername: to.Ptr(KubeadminUsername),
+		KubeadminPassword: to.Ptr(KubeadminPassword),
+	})
+}
+
+func (s *Server) listAdminCredentials(w http.ResponseWriter, id string) {
+	if failure := s.failures.take(OperationListAdminCredentials); failure != nil {
+		writeError(w, failure.StatusCode, failure.Code, failure.Message)
+		return
+	}
+
+	cluster, ok := s.clusters[strings.ToLower(id)]
+	if !ok {
+		writeError(w, http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("The Resource %q was not found.", id))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, adminKubeconfig(cluster))
+}
+
+// adminKubeconfig returns a kubeconfig for the API server of the cluster.
+func adminKubeconfig(cluster *redhatopenshift.OpenShiftCluster) redhatopenshift.OpenShiftClusterAdminKubeconfig {
+	kubeconfig := fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: %s
+  name: %s
+users:
+- name: system:admin
+  user:
+    token: fake-admin-token
+`, *cluster.Properties.ApiserverProfile.URL, *cluster.Name)
+
+	return redhatopenshift.OpenShiftClusterAdminKubeconfig{
+		Kubeconfig: to.Ptr(base64.StdEncoding.EncodeToString([]byte(kubeconfig))),
+	}
+}
+
+// startOperation records a long running operation on the cluster and points the response at its status.
+func (s *Server) startOperation(w http.ResponseWriter, cluster *redhatopenshift.OpenShiftCluster, kind string, failure *Failure) {
+	s.sequence++
+	now := time.Now()
+	op := &operation{
+		id:        fmt.Sprintf("%08d-0000-0000-0000-000000000000", s.sequence),
+		clusterId: strings.ToLower(*cluster.ID),
+		kind:      kind,
+		location:  strings.ToLower(strings.ReplaceAll(*cluster.Location, " ", "")),
+		start:     now,
+		end:       now.Add(s.options.OperationDelay),
+		failure:   failure,
+	}
+	s.operations[op.id] = op
+
+	statusUrl := fmt.Sprintf("%s/subscriptions/%s/providers/Microsoft.RedHatOpenShift/locations/%s/operationsStatus/%s?api-version=2023-09-04", s.URL(), SubscriptionID, op.location, op.id)
+	w.Header().Set("Azure-AsyncOperation", statusUrl)
+	if kind == OperationDelete {
+		w.Header().Set("Location", statusUrl)
+	}
+	w.Header().Set("x-ms-request-id", op.id)
+}
+
+// completeOperations completes the operations whose delay has elapsed, applying their outcome to the cluster.
+func (s *Server) completeOperations() {
+	now := time.Now()
+	for _, op := range s.operations {
+		if op.done || now.Before(op.end) {
+			continue
+		}
+		op.done = true
+
+		cluster, ok := s.clusters[op.clusterId]
+		if !ok {
+			continue
+		}
+
+		switch {
+		case op.failure != nil:
+			state := redhatopenshift.ProvisioningStateFailed
+			cluster.Properties.ProvisioningState = &state
+		case op.kind == OperationDelete:
+			delete(s.clusters, op.clusterId)
+			// as ARM, the locks of a resource are deleted along with it
+			for key := range s.locks {
+				if strings.HasPrefix(key, op.clusterId+"/providers/") {
+					delete(s.locks, key)
+				}
+			}
+		default:
+			state := redhatopenshift.ProvisioningStateSucceeded
+			cluster.Properties.ProvisioningState = &state
+		}
+	}
+}
+
+func (s *Server) serveOperationStatus(w http.ResponseWriter, r *http.Request, id string) {
+	op, ok := s.operations[id]
+	if !ok || r.Method != http.MethodGet {
+		writeError(w, http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("The operation %q was not found.", id))
+		return
+	}
+
+	body := map[string]interface{}{
+		"id":        r.URL.Path,
+		"name":      op.id,
+		"status":    op.status(),
+		"startTime": op.start.UTC().Format(time.RFC3339),
+	}
+
+	if op.done {
+		body["endTime"] = op.end.UTC().Format(time.RFC3339)
+	} else {
+		w.Header().Set("Retry-After", "1")
+	}
+
+	if op.done && op.failure != nil {
+		body["error"] = map[string]interface{}{
+			"code":    op.failure.Code,
+			"message": op.failure.Message,
+		}
+	}
+
+	writeJSON(w, http.StatusOK, body)
+}
+
+func isBusy(cluster *redhatopenshift.OpenShiftCluster) bool {
+	state := cluster.Properties.ProvisioningState
+	return state != nil && *state != redhatopenshift.ProvisioningStateSucceeded && *state != redhatopenshift.ProvisioningStateFailed
+}
+
+// populateDefaults sets the properties which ARO fills in when they are not specified, along with the read-only ones.
+// patchProperties applies the properties of an update to the cluster.  Like ARM, only the fields which are set are
+// changed, so that the pull secret can be updated on its own.
+func patchProperties(cluster *redhatopenshift.OpenShiftCluster, props *redhatopenshift.OpenShiftClusterProperties) {
+	if props.ClusterProfile != nil {
+		if cluster.Properties.ClusterProfile == nil {
+			cluster.Properties.ClusterProfile = &redhatopenshift.ClusterProfile{}
+		}
+		data, _ := json.Marshal(props.ClusterProfile)
+		_ = json.Unmarshal(data, cluster.Properties.ClusterProfile)
+	}
+	if props.ServicePrincipalProfile != nil {
+		cluster.Properties.ServicePrincipalProfile = props.ServicePrincipalProfile
+	}
+	if props.MasterProfile != nil {
+		cluster.Properties.MasterProfile = props.MasterProfile
+	}
+	if props.WorkerProfiles != nil {
+		cluster.Properties.WorkerProfiles = props.WorkerProfiles
+	}
+	populateDefaults(cluster)
+}
+
+func populateDefaults(cluster *redhatopenshift.OpenShiftCluster) {
+	props := cluster.Properties
+	// ARM returns the normalized location, whichever way it was written
+	location := strings.ToLower(strings.ReplaceAll(*cluster.Location, " ", ""))
+	cluster.Location = to.Ptr(location)
+
+	if props.ClusterProfile == nil {
+		props.ClusterProfile = &redhatopenshift.ClusterProfile{}
+	}
+	if props.ClusterProfile.Domain == nil || *props.ClusterProfile.Domain == "" {
+		props.ClusterProfile.Domain = to.Ptr(strings.ToLower(*cluster.Name))
+	}
+	if props.ClusterProfile.Version == nil || *props.ClusterProfile.Version == "" {
+		props.ClusterProfile.Version = to.Ptr(defaultVersion)
+	}
+	if props.ClusterProfile.ResourceGroupID == nil || *props.ClusterProfile.ResourceGroupID == "" {
+		props.ClusterProfile.ResourceGroupID = to.Ptr(fmt.Sprintf("/subscriptions/%s/resourceGroups/aro-%s", SubscriptionID, *props.ClusterProfile.Domain))
+	}
+	if props.ClusterProfile.FipsValidatedModules == nil {
+		props.ClusterProfile.FipsValidatedModules = to.Ptr(redhatopenshift.FipsValidatedModulesDisabled)
+	}
+
+	domain := *props.ClusterProfile.Domain
+	if !strings.Contains(domain, ".") {
+		domain = fmt.Sprintf("%s.%s.aroapp.io", domain, location)
+	}
+
+	if props.ConsoleProfile == nil {
+		props.ConsoleProfile = &redhatopenshift.ConsoleProfile{}
+	}
+	props.ConsoleProfile.URL = to.Ptr(fmt.Sprintf("https://console-openshift-console.apps.%s/", domain))
+
+	if props.ApiserverProfile == nil {
+		props.ApiserverProfile = &redhatopenshift.APIServerProfile{}
+	}
+	if props.ApiserverProfile.Visibility == nil {
+		props.ApiserverProfile.Visibility = to.Ptr(redhatopenshift.VisibilityPublic)
+	}
+	props.ApiserverProfile.URL = to.Ptr(fmt.Sprintf("https://api.%s:6443/", domain))
+	props.ApiserverProfile.IP = to.Ptr("20.0.0.10")
+
+	if len(props.IngressProfiles) == 0 {
+		props.IngressProfiles = []*redhatopenshift.IngressProfile{{}}
+	}
+	for _, ingress := range props.IngressProfiles {
+		if ingress.Name == nil {
+			ingress.Name = to.Ptr("default")
+		}
+		if ingress.Visibility == nil {
+			ingress.Visibility = to.Ptr(redhatopenshift.VisibilityPublic)
+		}
+		ingress.IP = to.Ptr("20.0.0.11")
+	}
+
+	if props.NetworkProfile == nil {
+		props.NetworkProfile = &redhatopenshift.NetworkProfile{}
+	}
+	if props.NetworkProfile.PodCidr == nil {
+		props.NetworkProfile.PodCidr = to.Ptr("10.128.0.0/14")
+	}
+	if props.NetworkProfile.ServiceCidr == nil {
+		props.NetworkProfile.ServiceCidr = to.Ptr("172.30.0.0/16")
+	}
+	if props.NetworkProfile.OutboundType == nil {
+		props.NetworkProfile.OutboundType = to.Ptr(redhatopenshift.OutboundTypeLoadbalancer)
+	}
+}
+
+// existingCluster returns a copy of the cluster, created outside of the provider, with the properties ARO fills in.
+func existingCluster(cluster redhatopenshift.OpenShiftCluster) *redhatopenshift.OpenShiftCluster {
+	stored := copyCluster(&cluster)
+	if stored.Name == nil {
+		stored.Name = to.Ptr((*stored.ID)[strings.LastIndex(*stored.ID, "/")+1:])
+	}
+	if stored.Properties == nil {
+		stored.Properties = &redhatopenshift.OpenShiftClusterProperties{}
+	}
+	if stored.Properties.ProvisioningState == nil {
+		stored.Properties.ProvisioningState = to.Ptr(redhatopenshift.ProvisioningStateSucceeded)
+	}
+	populateDefaults(stored)
+
+	return stored
+}
+
+// redacted returns a copy of the cluster without the secrets, which ARO never returns.
+func redacted(cluster *redhatopenshift.OpenShiftCluster) redhatopenshift.OpenShiftCluster {
+	data, _ := json.Marshal(cluster)
+
+	var out redhatopenshift.OpenShiftCluster
+	_ = json.Unmarshal(data, &out)
+
+	if props := out.Properties; props != nil {
+		if props.ClusterProfile != nil {
+			props.ClusterProfile.PullSecret = nil
+		}
+		if props.ServicePrincipalProfile != nil {
+			props.ServicePrincipalProfile.ClientSecret = nil
+		}
+	}
+
+	return out
+}
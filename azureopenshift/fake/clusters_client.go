@@ -0,0 +1,352 @@
+package fake
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	redhatopenshift "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/redhatopenshift/armredhatopenshift"
+
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/clients"
+)
+
+var (
+	_ clients.OpenShiftClustersClient    = &ClustersClient{}
+	_ clients.OpenShiftCredentialsClient = &ClustersClient{}
+)
+
+// ClustersClient is an in-memory fake of the clients.OpenShiftClustersClient and clients.OpenShiftCredentialsClient,
+// for unit tests of the resource which don't go through HTTP.  Its long running operations complete as soon as they're
+// polled, and it fails with the same *azcore.ResponseError as the SDK.
+type ClustersClient struct {
+	mu       sync.Mutex
+	clusters map[string]*redhatopenshift.OpenShiftCluster
+	failures failureQueue
+	calls    []string
+}
+
+// NewClustersClient returns a fake without any cluster.
+func NewClustersClient() *ClustersClient {
+	return &ClustersClient{
+		clusters: make(map[string]*redhatopenshift.OpenShiftCluster),
+	}
+}
+
+// ClusterID returns the ID of the cluster in the fake's subscription.
+func ClusterID(resourceGroupName string, resourceName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/%s/%s", SubscriptionID, resourceGroupName, clusterResourceType, resourceName)
+}
+
+// SetCluster stores the cluster as is, along with the read-only properties ARO fills in.  Its ID must be set.
+func (c *ClustersClient) SetCluster(cluster redhatopenshift.OpenShiftCluster) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stored := existingCluster(cluster)
+	c.clusters[strings.ToLower(*stored.ID)] = stored
+}
+
+// Cluster returns the cluster with the given ID, including its secrets.
+func (c *ClustersClient) Cluster(id string) (redhatopenshift.OpenShiftCluster, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cluster, ok := c.clusters[strings.ToLower(id)]
+	if !ok {
+		return redhatopenshift.OpenShiftCluster{}, false
+	}
+
+	return *copyCluster(cluster), true
+}
+
+// InjectFailure makes the calls matching f fail.  Failures are matched in the order they were injected.
+func (c *ClustersClient) InjectFailure(f Failure) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.failures.inject(f)
+}
+
+// Calls returns the operation and cluster name of every call made so far, e.g. `Get test-cluster`.
+func (c *ClustersClient) Calls() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]string(nil), c.calls...)
+}
+
+func (c *ClustersClient) Get(_ context.Context, resourceGroupName string, resourceName string) (redhatopenshift.OpenShiftClustersClientGetResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := c.call(OperationGet, resourceGroupName, resourceName)
+	if failure := c.failures.take(OperationGet); failure != nil {
+		return redhatopenshift.OpenShiftClustersClientGetResponse{}, failure.responseError(http.MethodGet, id)
+	}
+
+	cluster, ok := c.clusters[strings.ToLower(id)]
+	if !ok {
+		return redhatopenshift.OpenShiftClustersClientGetResponse{}, notFoundError(http.MethodGet, id)
+	}
+
+	return redhatopenshift.OpenShiftClustersClientGetResponse{OpenShiftCluster: redacted(cluster)}, nil
+}
+
+func (c *ClustersClient) BeginCreateOrUpdate(_ context.Context, resourceGroupName string, resourceName string, parameters redhatopenshift.OpenShiftCluster) (clients.Poller[redhatopenshift.OpenShiftClustersClientCreateOrUpdateResponse], error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := c.call(OperationCreateOrUpdate, resourceGroupName, resourceName)
+	failure := c.failures.take(OperationCreateOrUpdate)
+	if failure != nil && !failure.Async {
+		return nil, failure.responseError(http.MethodPut, id)
+	}
+
+	cluster := copyCluster(&parameters)
+	if cluster.Location == nil || cluster.Properties == nil {
+		return nil, responseError(http.MethodPut, id, http.StatusBadRequest, "InvalidRequestContent", "The `location` and `properties` of the cluster are required.")
+	}
+
+	cluster.ID = to.Ptr(id)
+	cluster.Name = to.Ptr(resourceName)
+	cluster.Type = to.Ptr(clusterResourceType)
+	populateDefaults(cluster)
+	c.clusters[strings.ToLower(id)] = cluster
+
+	if failure != nil {
+		cluster.Properties.ProvisioningState = to.Ptr(redhatopenshift.ProvisioningStateFailed)
+		return &poller[redhatopenshift.OpenShiftClustersClientCreateOrUpdateResponse]{err: failure.responseError(http.MethodPut, id)}, nil
+	}
+
+	cluster.Properties.ProvisioningState = to.Ptr(redhatopenshift.ProvisioningStateSucceeded)
+	return &poller[redhatopenshift.OpenShiftClustersClientCreateOrUpdateResponse]{
+		result: redhatopenshift.OpenShiftClustersClientCreateOrUpdateResponse{OpenShiftCluster: redacted(cluster)},
+	}, nil
+}
+
+func (c *ClustersClient) BeginUpdate(_ context.Context, resourceGroupName string, resourceName string, parameters redhatopenshift.OpenShiftClusterUpdate) (clients.Poller[redhatopenshift.OpenShiftClustersClientUpdateResponse], error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := c.call(OperationUpdate, resourceGroupName, resourceName)
+	failure := c.failures.take(OperationUpdate)
+	if failure != nil && !failure.Async {
+		return nil, failure.responseError(http.MethodPatch, id)
+	}
+
+	cluster, ok := c.clusters[strings.ToLower(id)]
+	if !ok {
+		return nil, notFoundError(http.MethodPatch, id)
+	}
+
+	if failure != nil {
+		cluster.Properties.ProvisioningState = to.Ptr(redhatopenshift.ProvisioningStateFailed)
+		return &poller[redhatopenshift.OpenShiftClustersClientUpdateResponse]{err: failure.responseError(http.MethodPatch, id)}, nil
+	}
+
+	if parameters.Tags != nil {
+		cluster.Tags = parameters.Tags
+	}
+	if props := parameters.Properties; props != nil {
+		patchProperties(cluster, props)
+	}
+	cluster.Properties.ProvisioningState = to.Ptr(redhatopenshift.ProvisioningStateSucceeded)
+
+	return &poller[redhatopenshift.OpenShiftClustersClientUpdateResponse]{
+		result: redhatopenshift.OpenShiftClustersClientUpdateResponse{OpenShiftCluster: redacted(cluster)},
+	}, nil
+}
+
+func (c *ClustersClient) BeginDelete(_ context.Context, resourceGroupName string, resourceName string) (clients.Poller[redhatopenshift.OpenShiftClustersClientDeleteResponse], error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := c.call(OperationDelete, resourceGroupName, resourceName)
+	failure := c.failures.take(OperationDelete)
+	if failure != nil && !failure.Async {
+		return nil, failure.responseError(http.MethodDelete, id)
+	}
+
+	if failure != nil {
+		if cluster, ok := c.clusters[strings.ToLower(id)]; ok {
+			cluster.Properties.ProvisioningState = to.Ptr(redhatopenshift.ProvisioningStateFailed)
+		}
+		return &poller[redhatopenshift.OpenShiftClustersClientDeleteResponse]{err: failure.responseError(http.MethodDelete, id)}, nil
+	}
+
+	delete(c.clusters, strings.ToLower(id))
+
+	return &poller[redhatopenshift.OpenShiftClustersClientDeleteResponse]{}, nil
+}
+
+func (c *ClustersClient) ListCredentials(_ context.Context, resourceGroupName string, resourceName string) (redhatopenshift.OpenShiftClustersClientListCredentialsResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := c.call(OperationListCredentials, resourceGroupName, resourceName)
+	if failure := c.failures.take(OperationListCredentials); failure != nil {
+		return redhatopenshift.OpenShiftClustersClientListCredentialsResponse{}, failure.responseError(http.MethodPost, id+"/listCredentials")
+	}
+
+	if _, ok := c.clusters[strings.ToLower(id)]; !ok {
+		return redhatopenshift.OpenShiftClustersClientListCredentialsResponse{}, notFoundError(http.MethodPost, id+"/listCredentials")
+	}
+
+	return redhatopenshift.OpenShiftClustersClientListCredentialsResponse{
+		OpenShiftClusterCredentials: redhatopenshift.OpenShiftClusterCredentials{
+			KubeadminUsername: to.Ptr(KubeadminUsername),
+			KubeadminPassword: to.Ptr(KubeadminPassword),
+		},
+	}, nil
+}
+
+func (c *ClustersClient) ListAdminCredentials(_ context.Context, resourceGroupName string, resourceName string) (redhatopenshift.OpenShiftClustersClientListAdminCredentialsResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := c.call(OperationListAdminCredentials, resourceGroupName, resourceName)
+	if failure := c.failures.take(OperationListAdminCredentials); failure != nil {
+		return redhatopenshift.OpenShiftClustersClientListAdminCredentialsResponse{}, failure.responseError(http.MethodPost, id+"/listAdminCredentials")
+	}
+
+	cluster, ok := c.clusters[strings.ToLower(id)]
+	if !ok {
+		return redhatopenshift.OpenShiftClustersClientListAdminCredentialsResponse{}, notFoundError(http.MethodPost, id+"/listAdminCredentials")
+	}
+
+	return redhatopenshift.OpenShiftClustersClientListAdminCredentialsResponse{
+		OpenShiftClusterAdminKubeconfig: adminKubeconfig(cluster),
+	}, nil
+}
+
+// NewListPager lists the clusters of the subscription in a single page, or fails with the first injected failure.
+func (c *ClustersClient) NewListPager() *runtime.Pager[redhatopenshift.OpenShiftClustersClientListResponse] {
+	return runtime.NewPager(runtime.PagingHandler[redhatopenshift.OpenShiftClustersClientListResponse]{
+		More: func(page redhatopenshift.OpenShiftClustersClientListResponse) bool {
+			return false
+		},
+		Fetcher: func(_ context.Context, _ *redhatopenshift.OpenShiftClustersClientListResponse) (redhatopenshift.OpenShiftClustersClientListResponse, error) {
+			clusters, err := c.list(OperationList, "")
+			return redhatopenshift.OpenShiftClustersClientListResponse{
+				OpenShiftClusterList: redhatopenshift.OpenShiftClusterList{Value: clusters},
+			}, err
+		},
+	})
+}
+
+// NewListByResourceGroupPager lists the clusters of the resource group in a single page, or fails with the first
+// injected failure.
+func (c *ClustersClient) NewListByResourceGroupPager(resourceGroupName string) *runtime.Pager[redhatopenshift.OpenShiftClustersClientListByResourceGroupResponse] {
+	return runtime.NewPager(runtime.PagingHandler[redhatopenshift.OpenShiftClustersClientListByResourceGroupResponse]{
+		More: func(page redhatopenshift.OpenShiftClustersClientListByResourceGroupResponse) bool {
+			return false
+		},
+		Fetcher: func(_ context.Context, _ *redhatopenshift.OpenShiftClustersClientListByResourceGroupResponse) (redhatopenshift.OpenShiftClustersClientListByResourceGroupResponse, error) {
+			clusters, err := c.list(OperationListByResourceGroup, resourceGroupName)
+			return redhatopenshift.OpenShiftClustersClientListByResourceGroupResponse{
+				OpenShiftClusterList: redhatopenshift.OpenShiftClusterList{Value: clusters},
+			}, err
+		},
+	})
+}
+
+// list returns the redacted clusters of the resource group, or of the subscription when it's empty, sorted by ID.
+func (c *ClustersClient) list(operation string, resourceGroupName string) ([]*redhatopenshift.OpenShiftCluster, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	path := fmt.Sprintf("/subscriptions/%s/providers/%s", SubscriptionID, clusterResourceType)
+	if resourceGroupName != "" {
+		path = fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/%s", SubscriptionID, resourceGroupName, clusterResourceType)
+	}
+
+	c.calls = append(c.calls, strings.TrimSpace(fmt.Sprintf("%s %s", operation, resourceGroupName)))
+	if failure := c.failures.take(operation); failure != nil {
+		return nil, failure.responseError(http.MethodGet, path)
+	}
+
+	prefix := strings.ToLower(path[:strings.Index(path, "/providers/")] + "/")
+	var clusters []*redhatopenshift.OpenShiftCluster
+	for id, cluster := range c.clusters {
+		if strings.HasPrefix(id, prefix) {
+			listed := redacted(cluster)
+			clusters = append(clusters, &listed)
+		}
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return *clusters[i].ID < *clusters[j].ID
+	})
+
+	return clusters, nil
+}
+
+// call records the call and returns the ID of the cluster it's made on.
+func (c *ClustersClient) call(operation string, resourceGroupName string, resourceName string) string {
+	c.calls = append(c.calls, fmt.Sprintf("%s %s", operation, resourceName))
+
+	return ClusterID(resourceGroupName, resourceName)
+}
+
+// poller is a long running operation which has already completed.
+type poller[T any] struct {
+	result T
+	err    error
+}
+
+func (p *poller[T]) PollUntilDone(_ context.Context, _ *runtime.PollUntilDoneOptions) (T, error) {
+	return p.result, p.err
+}
+
+func (f *Failure) responseError(method string, path string) error {
+	return responseError(method, path, f.StatusCode, f.Code, f.Message)
+}
+
+func notFoundError(method string, id string) error {
+	return responseError(method, id, http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("The Resource %q was not found.", id))
+}
+
+// responseError returns the *azcore.ResponseError the SDK returns for a failed request, with an ARM error body.
+func responseError(method string, path string, status int, code string, message string) error {
+	req, err := http.NewRequest(method, "https://management.azure.com"+path, nil)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	resp := &http.Response{
+		Request:    req,
+		StatusCode: status,
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Header:     http.Header{"Content-Type": []string{"application/json"}, "X-Ms-Error-Code": []string{code}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}
+
+	return runtime.NewResponseError(resp)
+}
+
+// copyCluster returns a deep copy of the cluster, so that the callers never share the fake's state.
+func copyCluster(cluster *redhatopenshift.OpenShiftCluster) *redhatopenshift.OpenShiftCluster {
+	data, _ := json.Marshal(cluster)
+
+	var out redhatopenshift.OpenShiftCluster
+	_ = json.Unmarshal(data, &out)
+
+	return &out
+}
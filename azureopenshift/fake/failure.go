@@ -0,0 +1,93 @@
+package fake
+
+// The OpenShiftClusters operations a Failure can be injected into.
+const (
+	OperationCreateOrUpdate       = "CreateOrUpdate"
+	OperationUpdate               = "Update"
+	OperationGet                  = "Get"
+	OperationDelete               = "Delete"
+	OperationList                 = "List"
+	OperationListByResourceGroup  = "ListByResourceGroup"
+	OperationListCredentials      = "ListCredentials"
+	OperationListAdminCredentials = "ListAdminCredentials"
+)
+
+// The ManagementLocks operations a Failure can be injected into.
+const (
+	OperationCreateLock = "CreateOrUpdateByScope"
+	OperationGetLock    = "GetByScope"
+	OperationDeleteLock = "DeleteByScope"
+)
+
+// Failure makes matching requests fail with an ARM error.
+type Failure struct {
+	// Operation is the operation which fails, one of the Operation constants.  An empty value matches every operation.
+	Operation string
+
+	// StatusCode is the status of the failed response, which defaults to 500 Internal Server Error.  It is ignored for
+	// asynchronous failures.
+	StatusCode int
+
+	// Code and Message are returned in the ARM error.
+	Code    string
+	Message string
+
+	// Async accepts the request of a long running operation but fails the operation itself.
+	Async bool
+
+	// Times is the number of matching requests which fail, 0 fails every matching request.
+	Times int
+}
+
+// failureQueue holds the injected failures, in the order they were injected.
+type failureQueue []*Failure
+
+func (q *failureQueue) inject(f Failure) {
+	if f.StatusCode == 0 {
+		f.StatusCode = 500
+	}
+	if f.Code == "" {
+		f.Code = "InternalServerError"
+	}
+	if f.Message == "" {
+		f.Message = "An injected failure occurred."
+	}
+
+	*q = append(*q, &f)
+}
+
+// take returns the first failure matching the operation, consuming one of its occurrences.
+func (q *failureQueue) take(operation string) *Failure {
+	for i, f := range *q {
+		if f.Operation != "" && f.Operation != operation {
+			continue
+		}
+
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				*q = append((*q)[:i], (*q)[i+1:]...)
+			}
+		}
+
+		return f
+	}
+
+	return nil
+}
+
+// InjectFailure makes the requests matching f fail.  Failures are matched in the order they were injected.
+func (s *Server) InjectFailure(f Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures.inject(f)
+}
+
+// ClearFailures removes every injected failure.
+func (s *Server) ClearFailures() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = nil
+}
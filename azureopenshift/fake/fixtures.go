@@ -0,0 +1,51 @@
+package fake
+
+import (
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	redhatopenshift "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/redhatopenshift/armredhatopenshift"
+)
+
+// The cluster the tests create, in the fake's subscription.
+const (
+	ResourceGroupName  = "test-rg"
+	ClusterName        = "test-cluster"
+	ClusterDomain      = "abcdefgh"
+	ClusterPullSecret  = `{"auths":{}}`
+	ClusterSecret      = "cluster-secret"
+	ClusterEnvironment = "dev"
+)
+
+// SubnetID returns the ID of the subnet with the given name in the virtual network of the tests' clusters.
+func SubnetID(name string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/network-rg/providers/Microsoft.Network/virtualNetworks/aro-vnet/subnets/%s", SubscriptionID, name)
+}
+
+// NewCluster returns the cluster the tests create, with the given name, as ARO is asked to create it.
+func NewCluster(resourceGroupName string, name string) redhatopenshift.OpenShiftCluster {
+	return redhatopenshift.OpenShiftCluster{
+		ID:       to.Ptr(ClusterID(resourceGroupName, name)),
+		Location: to.Ptr("eastus"),
+		Tags:     map[string]*string{"env": to.Ptr(ClusterEnvironment)},
+		Properties: &redhatopenshift.OpenShiftClusterProperties{
+			ClusterProfile: &redhatopenshift.ClusterProfile{
+				Domain:     to.Ptr(ClusterDomain),
+				PullSecret: to.Ptr(ClusterPullSecret),
+			},
+			MasterProfile: &redhatopenshift.MasterProfile{
+				SubnetID: to.Ptr(SubnetID("master")),
+			},
+			WorkerProfiles: []*redhatopenshift.WorkerProfile{
+				{
+					Name:     to.Ptr("worker"),
+					SubnetID: to.Ptr(SubnetID("worker")),
+				},
+			},
+			ServicePrincipalProfile: &redhatopenshift.ServicePrincipalProfile{
+				ClientID:     to.Ptr(ClientID),
+				ClientSecret: to.Ptr(ClusterSecret),
+			},
+		},
+	}
+}
@@ -0,0 +1,103 @@
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armlocks"
+)
+
+const lockResourceType = "Microsoft.Authorization/locks"
+
+// Lock returns the lock with the given name on the scope.
+func (s *Server) Lock(scope string, lockName string) (armlocks.ManagementLockObject, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lock, ok := s.locks[lockKey(scope, lockName)]
+	return lock, ok
+}
+
+// SetLock creates the lock with the given name and level on the scope, as if it was created outside of the provider.
+func (s *Server) SetLock(scope string, lockName string, level armlocks.LockLevel) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.locks[lockKey(scope, lockName)] = armlocks.ManagementLockObject{
+		ID:         to.Ptr(lockId(scope, lockName)),
+		Name:       to.Ptr(lockName),
+		Type:       to.Ptr(lockResourceType),
+		Properties: &armlocks.ManagementLockProperties{Level: to.Ptr(level)},
+	}
+}
+
+// RemoveLock removes the lock with the given name from the scope, as if it was deleted outside of the provider.
+func (s *Server) RemoveLock(scope string, lockName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.locks, lockKey(scope, lockName))
+}
+
+// serveLock serves the management lock with the given name on the cluster with the given ID.
+func (s *Server) serveLock(w http.ResponseWriter, r *http.Request, clusterId string, lockName string) {
+	key := lockKey(clusterId, lockName)
+
+	switch r.Method {
+	case http.MethodPut:
+		if failure := s.failures.take(OperationCreateLock); failure != nil {
+			writeError(w, failure.StatusCode, failure.Code, failure.Message)
+			return
+		}
+
+		var lock armlocks.ManagementLockObject
+		if err := json.NewDecoder(r.Body).Decode(&lock); err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+			return
+		}
+		if lock.Properties == nil || lock.Properties.Level == nil {
+			writeError(w, http.StatusBadRequest, "InvalidRequestContent", "The `level` of the lock is required.")
+			return
+		}
+
+		lock.ID = to.Ptr(lockId(clusterId, lockName))
+		lock.Name = to.Ptr(lockName)
+		lock.Type = to.Ptr(lockResourceType)
+
+		status := http.StatusCreated
+		if _, exists := s.locks[key]; exists {
+			status = http.StatusOK
+		}
+		s.locks[key] = lock
+		writeJSON(w, status, lock)
+	case http.MethodGet:
+		if failure := s.failures.take(OperationGetLock); failure != nil {
+			writeError(w, failure.StatusCode, failure.Code, failure.Message)
+			return
+		}
+
+		lock, ok := s.locks[key]
+		if !ok {
+			writeError(w, http.StatusNotFound, "LockNotFound", fmt.Sprintf("The lock %q was not found.", lockName))
+			return
+		}
+		writeJSON(w, http.StatusOK, lock)
+	case http.MethodDelete:
+		if failure := s.failures.take(OperationDeleteLock); failure != nil {
+			writeError(w, failure.StatusCode, failure.Code, failure.Message)
+			return
+		}
+
+		if _, ok := s.locks[key]; !ok {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		delete(s.locks, key)
+		w.WriteHeader(http.StatusOK)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", fmt.Sprintf("%s is not supported on %q.", r.Method, strings.TrimSuffix(r.URL.Path, "/")))
+	}
+}
@@ -0,0 +1,98 @@
+package fake
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armlocks"
+
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/clients"
+)
+
+var _ clients.ManagementLocksClient = &ManagementLocksClient{}
+
+// ManagementLocksClient is an in-memory fake of the clients.ManagementLocksClient.
+type ManagementLocksClient struct {
+	mu       sync.Mutex
+	locks    map[string]armlocks.ManagementLockObject
+	failures failureQueue
+}
+
+// NewManagementLocksClient returns a fake without any lock.
+func NewManagementLocksClient() *ManagementLocksClient {
+	return &ManagementLocksClient{
+		locks: make(map[string]armlocks.ManagementLockObject),
+	}
+}
+
+// Lock returns the lock with the given name on the scope.
+func (c *ManagementLocksClient) Lock(scope string, lockName string) (armlocks.ManagementLockObject, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	lock, ok := c.locks[lockKey(scope, lockName)]
+	return lock, ok
+}
+
+// InjectFailure makes the calls matching f fail.  Failures are matched in the order they were injected.
+func (c *ManagementLocksClient) InjectFailure(f Failure) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.failures.inject(f)
+}
+
+func (c *ManagementLocksClient) CreateOrUpdateByScope(_ context.Context, scope string, lockName string, parameters armlocks.ManagementLockObject, _ *armlocks.ManagementLocksClientCreateOrUpdateByScopeOptions) (armlocks.ManagementLocksClientCreateOrUpdateByScopeResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if f := c.failures.take(OperationCreateLock); f != nil {
+		return armlocks.ManagementLocksClientCreateOrUpdateByScopeResponse{}, f.responseError(http.MethodPut, lockId(scope, lockName))
+	}
+
+	parameters.ID = to.Ptr(lockId(scope, lockName))
+	parameters.Name = to.Ptr(lockName)
+	c.locks[lockKey(scope, lockName)] = parameters
+
+	return armlocks.ManagementLocksClientCreateOrUpdateByScopeResponse{ManagementLockObject: parameters}, nil
+}
+
+func (c *ManagementLocksClient) DeleteByScope(_ context.Context, scope string, lockName string, _ *armlocks.ManagementLocksClientDeleteByScopeOptions) (armlocks.ManagementLocksClientDeleteByScopeResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if f := c.failures.take(OperationDeleteLock); f != nil {
+		return armlocks.ManagementLocksClientDeleteByScopeResponse{}, f.responseError(http.MethodDelete, lockId(scope, lockName))
+	}
+
+	delete(c.locks, lockKey(scope, lockName))
+
+	return armlocks.ManagementLocksClientDeleteByScopeResponse{}, nil
+}
+
+func (c *ManagementLocksClient) GetByScope(_ context.Context, scope string, lockName string, _ *armlocks.ManagementLocksClientGetByScopeOptions) (armlocks.ManagementLocksClientGetByScopeResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if f := c.failures.take(OperationGetLock); f != nil {
+		return armlocks.ManagementLocksClientGetByScopeResponse{}, f.responseError(http.MethodGet, lockId(scope, lockName))
+	}
+
+	lock, ok := c.locks[lockKey(scope, lockName)]
+	if !ok {
+		return armlocks.ManagementLocksClientGetByScopeResponse{}, responseError(http.MethodGet, lockId(scope, lockName), http.StatusNotFound, "LockNotFound", "The lock was not found.")
+	}
+
+	return armlocks.ManagementLocksClientGetByScopeResponse{ManagementLockObject: lock}, nil
+}
+
+func lockId(scope string, lockName string) string {
+	return strings.TrimSuffix(scope, "/") + "/providers/Microsoft.Authorization/locks/" + lockName
+}
+
+func lockKey(scope string, lockName string) string {
+	return strings.ToLower(lockId(scope, lockName))
+}
@@ -0,0 +1,235 @@
+// Package fake provides an in-memory fake of the Azure Resource Manager and Azure Active Directory endpoints used by
+// the provider, so that the Red Hat OpenShift Cluster resource can be exercised end to end without a subscription.
+package fake
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	redhatopenshift "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/redhatopenshift/armredhatopenshift"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armlocks"
+
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/auth"
+)
+
+const (
+	SubscriptionID = "00000000-0000-0000-0000-000000000000"
+	TenantID       = "11111111-1111-1111-1111-111111111111"
+	ClientID       = "22222222-2222-2222-2222-222222222222"
+	ClientSecret   = "fake-client-secret"
+	AccessToken    = "fake-access-token"
+
+	KubeadminUsername = "kubeadmin"
+	KubeadminPassword = "fake-kubeadmin-password"
+)
+
+// Options configure the behaviour of the fake.
+type Options struct {
+	// OperationDelay is how long long running operations stay in progress before completing.
+	OperationDelay time.Duration
+}
+
+// Server is an in-memory fake of the endpoints used by the provider.  It serves the ARM metadata discovery document
+// and a stub token endpoint, so that the provider authenticates against it, the OpenShiftClusters operations of the
+// Microsoft.RedHatOpenShift resource provider, including the polling of long running operations, and the management
+// locks of the clusters.  Requests for any other resource are answered with a 404.
+type Server struct {
+	server  *httptest.Server
+	options Options
+
+	mu         sync.Mutex
+	clusters   map[string]*redhatopenshift.OpenShiftCluster
+	locks      map[string]armlocks.ManagementLockObject
+	operations map[string]*operation
+	failures   failureQueue
+	requests   []string
+	sequence   int
+}
+
+// NewServer starts a fake listening on a local TLS port, which must be closed once done with.
+func NewServer(options Options) *Server {
+	s := &Server{
+		options:    options,
+		clusters:   make(map[string]*redhatopenshift.OpenShiftCluster),
+		locks:      make(map[string]armlocks.ManagementLockObject),
+		operations: make(map[string]*operation),
+	}
+	s.server = httptest.NewTLSServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// URL returns the base URL of the fake, which serves as both the Resource Manager and the Active Directory endpoint.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Close shuts the fake down.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// WriteCABundle writes the certificate the fake serves to path in PEM format, for use as the provider's
+// `ca_bundle_path`.
+func (s *Server) WriteCABundle(path string) error {
+	cert := s.server.Certificate()
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+
+	return os.WriteFile(path, data, 0o600)
+}
+
+// AuthConfig returns a configuration pointing the provider's clients at the fake, trusting the CA bundle written by
+// WriteCABundle to caBundlePath.
+func (s *Server) AuthConfig(caBundlePath string) auth.Config {
+	return auth.Config{
+		SubscriptionId:          SubscriptionID,
+		TenantId:                TenantID,
+		ClientId:                ClientID,
+		ClientSecret:            ClientSecret,
+		Environment:             auth.AzurePublicString,
+		AuthMethod:              auth.AuthMethodClientSecret,
+		ResourceManagerEndpoint: s.URL(),
+		CABundlePath:            caBundlePath,
+		MaxRetries:              0,
+		OperationRetryAttempts:  1,
+	}
+}
+
+// ProviderConfig returns the provider block pointing the provider at the fake, trusting the CA bundle written by
+// WriteCABundle to caBundlePath, to be prepended to the configuration of acceptance tests.  The attributes, such as
+// `deletion_protection_tag = "do-not-delete"`, are added to the block.
+func (s *Server) ProviderConfig(caBundlePath string, attributes ...string) string {
+	var extra strings.Builder
+	for _, attribute := range attributes {
+		extra.WriteString("  " + attribute + "\n")
+	}
+
+	return fmt.Sprintf(`
+provider "azureopenshift" {
+  subscription_id           = %q
+  tenant_id                 = %q
+  client_id                 = %q
+  client_secret             = %q
+  auth_method               = "client_secret"
+  resource_manager_endpoint = %q
+  ca_bundle_path            = %q
+  max_retries               = 0
+  operation_retry_attempts  = 1
+%s}
+`, SubscriptionID, TenantID, ClientID, ClientSecret, s.URL(), caBundlePath, extra.String())
+}
+
+// Requests returns the method and path of every request served so far, e.g. `GET /subscriptions/...`.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.requests...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+	s.completeOperations()
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch {
+	case r.URL.Path == "/metadata/endpoints":
+		s.serveMetadata(w)
+	case r.URL.Path == "/common/discovery/instance":
+		s.serveInstanceDiscovery(w)
+	case len(segments) == 4 && segments[1] == "v2.0" && segments[2] == ".well-known" && segments[3] == "openid-configuration":
+		s.serveOpenIDConfiguration(w, segments[0])
+	case len(segments) == 4 && segments[1] == "oauth2" && segments[2] == "v2.0" && segments[3] == "token":
+		s.serveToken(w, r)
+	case len(segments) > 0 && strings.EqualFold(segments[0], "subscriptions"):
+		if r.Header.Get("Authorization") != "Bearer "+AccessToken {
+			writeError(w, http.StatusUnauthorized, "InvalidAuthenticationToken", "The access token is invalid.")
+			return
+		}
+		// as ARM, every response carries a request ID, which long running operations replace by the operation's
+		w.Header().Set("x-ms-request-id", fmt.Sprintf("%08d-1111-1111-1111-111111111111", len(s.requests)))
+		s.serveResourceManager(w, r, segments)
+	default:
+		writeError(w, http.StatusNotFound, "NotFound", fmt.Sprintf("No route matches %s %s.", r.Method, r.URL.Path))
+	}
+}
+
+func (s *Server) serveMetadata(w http.ResponseWriter) {
+	endpoint := s.URL() + "/"
+	writeJSON(w, http.StatusOK, []map[string]interface{}{
+		{
+			"name":            "FakeCloud",
+			"resourceManager": endpoint,
+			"authentication": map[string]interface{}{
+				"loginEndpoint": endpoint,
+				"audiences":     []string{endpoint},
+				"tenant":        "common",
+			},
+		},
+	})
+}
+
+func (s *Server) serveInstanceDiscovery(w http.ResponseWriter) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"tenant_discovery_endpoint": fmt.Sprintf("%s/%s/v2.0/.well-known/openid-configuration", s.URL(), TenantID),
+		"api-version":               "1.1",
+		"metadata":                  []interface{}{},
+	})
+}
+
+func (s *Server) serveOpenIDConfiguration(w http.ResponseWriter, tenant string) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"authorization_endpoint": fmt.Sprintf("%s/%s/oauth2/v2.0/authorize", s.URL(), tenant),
+		"token_endpoint":         fmt.Sprintf("%s/%s/oauth2/v2.0/token", s.URL(), tenant),
+		"issuer":                 fmt.Sprintf("%s/%s/v2.0", s.URL(), tenant),
+	})
+}
+
+func (s *Server) serveToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{"error": "invalid_request", "error_description": err.Error()})
+		return
+	}
+
+	if r.PostForm.Get("client_id") != ClientID || r.PostForm.Get("client_secret") != ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]interface{}{
+			"error":             "invalid_client",
+			"error_description": "AADSTS7000215: Invalid client secret provided.",
+		})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"token_type":     "Bearer",
+		"expires_in":     3600,
+		"ext_expires_in": 3600,
+		"access_token":   AccessToken,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, code string, message string) {
+	w.Header().Set("x-ms-error-code", code)
+	writeJSON(w, status, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
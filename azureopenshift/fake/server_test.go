@@ -0,0 +1,212 @@
+package fake_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	redhatopenshift "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/redhatopenshift/armredhatopenshift"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armlocks"
+
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/clients"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/fake"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/aroerrors"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/utils"
+)
+
+func newTestClient(t *testing.T, options fake.Options) (*fake.Server, *clients.Client) {
+	t.Helper()
+
+	server := fake.NewServer(options)
+	t.Cleanup(server.Close)
+
+	caBundlePath := filepath.Join(t.TempDir(), "ca.pem")
+	if err := server.WriteCABundle(caBundlePath); err != nil {
+		t.Fatalf("writing CA bundle: %+v", err)
+	}
+
+	client, diags := clients.NewClient(context.Background(), context.Background(), server.AuthConfig(caBundlePath))
+	if diags.HasError() {
+		t.Fatalf("building client: %+v", diags)
+	}
+
+	return server, client
+}
+
+func TestServer_ClusterLifecycle(t *testing.T) {
+	ctx := context.Background()
+	server, client := newTestClient(t, fake.Options{})
+
+	poller, err := client.OpenShiftClustersClient.BeginCreateOrUpdate(ctx, fake.ResourceGroupName, fake.ClusterName, fake.NewCluster(fake.ResourceGroupName, fake.ClusterName))
+	if err != nil {
+		t.Fatalf("creating cluster: %+v", err)
+	}
+	created, err := poller.PollUntilDone(ctx, nil)
+	if err != nil {
+		t.Fatalf("waiting for the cluster creation: %+v", err)
+	}
+	if state := *created.Properties.ProvisioningState; state != redhatopenshift.ProvisioningStateSucceeded {
+		t.Fatalf("expected the cluster to be Succeeded, got %s", state)
+	}
+	if created.Properties.ClusterProfile.PullSecret != nil || created.Properties.ServicePrincipalProfile.ClientSecret != nil {
+		t.Fatalf("expected the secrets not to be returned")
+	}
+	if rg := *created.Properties.ClusterProfile.ResourceGroupID; !strings.HasSuffix(rg, "/resourceGroups/aro-abcdefgh") {
+		t.Fatalf("unexpected cluster resource group %q", rg)
+	}
+
+	creds, err := client.OpenShiftCredentialsClient.ListCredentials(ctx, fake.ResourceGroupName, fake.ClusterName)
+	if err != nil {
+		t.Fatalf("listing credentials: %+v", err)
+	}
+	if *creds.KubeadminUsername != fake.KubeadminUsername || *creds.KubeadminPassword != fake.KubeadminPassword {
+		t.Fatalf("unexpected credentials %+v", creds)
+	}
+
+	kubeconfig, err := client.OpenShiftCredentialsClient.ListAdminCredentials(ctx, fake.ResourceGroupName, fake.ClusterName)
+	if err != nil {
+		t.Fatalf("listing admin credentials: %+v", err)
+	}
+	if kubeconfig.Kubeconfig == nil || *kubeconfig.Kubeconfig == "" {
+		t.Fatalf("expected a kubeconfig")
+	}
+
+	updatePoller, err := client.OpenShiftClustersClient.BeginUpdate(ctx, fake.ResourceGroupName, fake.ClusterName, redhatopenshift.OpenShiftClusterUpdate{
+		Tags: map[string]*string{"env": to.Ptr("prod")},
+	})
+	if err != nil {
+		t.Fatalf("updating cluster: %+v", err)
+	}
+	updated, err := updatePoller.PollUntilDone(ctx, nil)
+	if err != nil {
+		t.Fatalf("waiting for the cluster update: %+v", err)
+	}
+	if env := *updated.Tags["env"]; env != "prod" {
+		t.Fatalf("expected the `env` tag to be updated, got %q", env)
+	}
+
+	deletePoller, err := client.OpenShiftClustersClient.BeginDelete(ctx, fake.ResourceGroupName, fake.ClusterName)
+	if err != nil {
+		t.Fatalf("deleting cluster: %+v", err)
+	}
+	if _, err := deletePoller.PollUntilDone(ctx, nil); err != nil {
+		t.Fatalf("waiting for the cluster deletion: %+v", err)
+	}
+
+	if _, err := client.OpenShiftClustersClient.Get(ctx, fake.ResourceGroupName, fake.ClusterName); !utils.ResponseWasNotFound(err) {
+		t.Fatalf("expected the cluster to be gone, got %+v", err)
+	}
+
+	if len(server.Requests()) == 0 {
+		t.Fatalf("expected the requests to be recorded")
+	}
+}
+
+func TestServer_OperationDelay(t *testing.T) {
+	ctx := context.Background()
+	server, client := newTestClient(t, fake.Options{OperationDelay: 1500 * time.Millisecond})
+
+	poller, err := client.OpenShiftClustersClient.BeginCreateOrUpdate(ctx, fake.ResourceGroupName, fake.ClusterName, fake.NewCluster(fake.ResourceGroupName, fake.ClusterName))
+	if err != nil {
+		t.Fatalf("creating cluster: %+v", err)
+	}
+
+	cluster, ok := server.Cluster(fake.ClusterID(fake.ResourceGroupName, fake.ClusterName))
+	if !ok {
+		t.Fatalf("expected the cluster to exist while it's created")
+	}
+	if state := *cluster.Properties.ProvisioningState; state != redhatopenshift.ProvisioningStateCreating {
+		t.Fatalf("expected the cluster to be Creating, got %s", state)
+	}
+
+	if _, err := poller.PollUntilDone(ctx, nil); err != nil {
+		t.Fatalf("waiting for the cluster creation: %+v", err)
+	}
+}
+
+func TestServer_InjectedFailures(t *testing.T) {
+	ctx := context.Background()
+	server, client := newTestClient(t, fake.Options{})
+
+	server.InjectFailure(fake.Failure{
+		Operation:  fake.OperationCreateOrUpdate,
+		StatusCode: 400,
+		Code:       "InvalidLinkedVNet",
+		Message:    "The provided subnet is invalid.",
+		Times:      1,
+	})
+
+	_, err := client.OpenShiftClustersClient.BeginCreateOrUpdate(ctx, fake.ResourceGroupName, fake.ClusterName, fake.NewCluster(fake.ResourceGroupName, fake.ClusterName))
+	if _, cloudError, ok := aroerrors.Unpack(err); !ok || cloudError == nil || cloudError.Code != "InvalidLinkedVNet" {
+		t.Fatalf("expected an InvalidLinkedVNet error, got %+v", err)
+	}
+
+	server.InjectFailure(fake.Failure{
+		Operation: fake.OperationCreateOrUpdate,
+		Code:      "ResourceQuotaExceeded",
+		Async:     true,
+		Times:     1,
+	})
+
+	poller, err := client.OpenShiftClustersClient.BeginCreateOrUpdate(ctx, fake.ResourceGroupName, fake.ClusterName, fake.NewCluster(fake.ResourceGroupName, fake.ClusterName))
+	if err != nil {
+		t.Fatalf("expected the request to be accepted, got %+v", err)
+	}
+	_, err = poller.PollUntilDone(ctx, nil)
+	if _, cloudError, ok := aroerrors.Unpack(err); !ok || cloudError == nil || cloudError.Code != "ResourceQuotaExceeded" {
+		t.Fatalf("expected the operation to fail with ResourceQuotaExceeded, got %+v", err)
+	}
+
+	cluster, err := client.OpenShiftClustersClient.Get(ctx, fake.ResourceGroupName, fake.ClusterName)
+	if err != nil {
+		t.Fatalf("retrieving cluster: %+v", err)
+	}
+	if state := *cluster.Properties.ProvisioningState; state != redhatopenshift.ProvisioningStateFailed {
+		t.Fatalf("expected the cluster to be Failed, got %s", state)
+	}
+}
+
+func TestServer_Locks(t *testing.T) {
+	ctx := context.Background()
+	server, client := newTestClient(t, fake.Options{})
+	id := fake.ClusterID(fake.ResourceGroupName, fake.ClusterName)
+	server.SetCluster(fake.NewCluster(fake.ResourceGroupName, fake.ClusterName))
+
+	if _, err := client.ManagementLocksClient.GetByScope(ctx, id, "lock", nil); !utils.ResponseWasNotFound(err) {
+		t.Fatalf("expected no lock, got %+v", err)
+	}
+
+	_, err := client.ManagementLocksClient.CreateOrUpdateByScope(ctx, id, "lock", armlocks.ManagementLockObject{
+		Properties: &armlocks.ManagementLockProperties{Level: to.Ptr(armlocks.LockLevelCanNotDelete)},
+	}, nil)
+	if err != nil {
+		t.Fatalf("creating lock: %+v", err)
+	}
+	lock, err := client.ManagementLocksClient.GetByScope(ctx, id, "lock", nil)
+	if err != nil {
+		t.Fatalf("retrieving lock: %+v", err)
+	}
+	if level := *lock.Properties.Level; level != armlocks.LockLevelCanNotDelete {
+		t.Fatalf("expected a CanNotDelete lock, got %s", level)
+	}
+
+	server.InjectFailure(fake.Failure{Operation: fake.OperationGetLock, StatusCode: 403, Code: "AuthorizationFailed", Times: 1})
+	if _, err := client.ManagementLocksClient.GetByScope(ctx, id, "lock", nil); !utils.ResponseWasForbidden(err) {
+		t.Fatalf("expected the injected failure, got %+v", err)
+	}
+
+	// the locks are deleted along with the cluster
+	poller, err := client.OpenShiftClustersClient.BeginDelete(ctx, fake.ResourceGroupName, fake.ClusterName)
+	if err != nil {
+		t.Fatalf("deleting cluster: %+v", err)
+	}
+	if _, err := poller.PollUntilDone(ctx, nil); err != nil {
+		t.Fatalf("waiting for the cluster deletion: %+v", err)
+	}
+	if _, ok := server.Lock(id, "lock"); ok {
+		t.Fatalf("expected the lock to be deleted along with the cluster")
+	}
+}
@@ -0,0 +1,165 @@
+package azureopenshift
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/clients"
+)
+
+// frameworkProvider serves the resources and every other feature of the provider.  It's muxed with the SDKv2 provider,
+// which only validates the provider block and configures the client: both providers share that *clients.Client, so the
+// provider block is only validated and authenticated once.
+type frameworkProvider struct {
+	sdkProvider *sdkschema.Provider
+}
+
+var (
+	_ provider.ProviderWithEphemeralResources = &frameworkProvider{}
+	_ provider.ProviderWithFunctions          = &frameworkProvider{}
+	_ provider.ProviderWithListResources      = &frameworkProvider{}
+)
+
+// NewFrameworkProvider returns the framework provider sharing the client of the SDKv2 provider.
+func NewFrameworkProvider(sdkProvider *sdkschema.Provider) provider.Provider {
+	return &frameworkProvider{sdkProvider: sdkProvider}
+}
+
+func (p *frameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "azureopenshift"
+}
+
+// Schema must be identical to the schema of the SDKv2 provider for the servers to be muxed, down to the descriptions.
+// Attributes which are Required there with a default from the environment are Optional to Terraform.  The validation
+// and defaults are left to the SDKv2 provider.
+func (p *frameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	sdkSchema := p.sdkProvider.Schema
+
+	stringAttribute := func(name string) schema.StringAttribute {
+		return schema.StringAttribute{
+			Optional:    true,
+			Sensitive:   sdkSchema[name].Sensitive,
+			Description: sdkSchema[name].Description,
+		}
+	}
+	int64Attribute := func(name string) schema.Int64Attribute {
+		return schema.Int64Attribute{
+			Optional:    true,
+			Description: sdkSchema[name].Description,
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"subscription_id":                stringAttribute("subscription_id"),
+			"client_id":                      stringAttribute("client_id"),
+			"client_secret":                  stringAttribute("client_secret"),
+			"client_certificate_path":        stringAttribute("client_certificate_path"),
+			"client_certificate":             stringAttribute("client_certificate"),
+			"client_certificate_password":    stringAttribute("client_certificate_password"),
+			"tenant_id":                      stringAttribute("tenant_id"),
+			"environment":                    stringAttribute("environment"),
+			"metadata_host":                  stringAttribute("metadata_host"),
+			"resource_manager_endpoint":      stringAttribute("resource_manager_endpoint"),
+			"max_retries":                    int64Attribute("max_retries"),
+			"retry_delay":                    stringAttribute("retry_delay"),
+			"max_retry_delay":                stringAttribute("max_retry_delay"),
+			"request_timeout":                stringAttribute("request_timeout"),
+			"proxy_url":                      stringAttribute("proxy_url"),
+			"ca_bundle_path":                 stringAttribute("ca_bundle_path"),
+			"operation_retry_attempts":       int64Attribute("operation_retry_attempts"),
+			"operation_retry_max_backoff":    stringAttribute("operation_retry_max_backoff"),
+			"max_concurrent_cluster_creates": int64Attribute("max_concurrent_cluster_creates"),
+			"partner_id":                     stringAttribute("partner_id"),
+			"user_agent_suffix":              stringAttribute("user_agent_suffix"),
+			"auth_method":                    stringAttribute("auth_method"),
+			"deletion_protection_tag":        stringAttribute("deletion_protection_tag"),
+		},
+		Blocks: map[string]schema.Block{
+			"default_tags": schema.ListNestedBlock{
+				Description: sdkSchema["default_tags"].Description,
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"tags": schema.MapAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"ignore_tags": schema.ListNestedBlock{
+				Description: sdkSchema["ignore_tags"].Description,
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"keys": schema.SetAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+						},
+						"key_prefixes": schema.SetAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure hands the client of the SDKv2 provider to the framework resources.  The mux server configures the SDKv2
+// provider first and stops at its errors, so its client is always set by then.
+func (p *frameworkProvider) Configure(_ context.Context, _ provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	client, ok := p.sdkProvider.Meta().(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Provider not configured", "The SDKv2 provider must be configured before the framework provider, serve them with ProtoV5ProviderServerFactory.")
+		return
+	}
+
+	resp.DataSourceData = client
+	resp.ResourceData = client
+	resp.EphemeralResourceData = client
+	resp.ListResourceData = client
+}
+
+func (p *frameworkProvider) Resources(_ context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		newOpenShiftClusterResource,
+		newOpenShiftClusterAzureRMResource,
+	}
+}
+
+func (p *frameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *frameworkProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		newOpenShiftClusterCredentialsEphemeralResource,
+	}
+}
+
+func (p *frameworkProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		newOpenShiftClusterListResource,
+	}
+}
+
+func (p *frameworkProvider) Functions(_ context.Context) []func() function.Function {
+	return providerFunctions
+}
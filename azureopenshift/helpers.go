@@ -2,31 +2,41 @@ package azureopenshift
 
 import (
 	"fmt"
-	"math/rand"
-	"time"
-)
 
-func randomString(acceptedChars string, size int) string {
-	charSet := []rune(acceptedChars)
-	randomChars := make([]rune, size)
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/aroerrors"
+)
 
-	rand.Seed(time.Now().UnixNano())
+func ResourceGroupID(subscriptionId string, resourceGroupName string) string {
+	fmtString := "/subscriptions/%s/resourceGroups/%s"
+	return fmt.Sprintf(fmtString, subscriptionId, resourceGroupName)
+}
 
-	for i := range randomChars {
-		randomChars[i] = charSet[rand.Intn(len(charSet))]
+// apiErrorDiagnostics converts the diagnostics of the API errors.  attributePath returns the path of the attribute a
+// diagnostic is about, or false when it's about none; it may be nil when there is no attribute to point at.
+func apiErrorDiagnostics(diags []aroerrors.Diagnostic, attributePath func(attribute []string) (path.Path, bool)) diag.Diagnostics {
+	var converted diag.Diagnostics
+	for _, d := range diags {
+		if attributePath != nil && len(d.Attribute) > 0 {
+			if p, ok := attributePath(d.Attribute); ok {
+				converted.AddAttributeError(p, d.Summary, d.Detail)
+				continue
+			}
+		}
+		converted.AddError(d.Summary, d.Detail)
 	}
 
-	return string(randomChars)
+	return converted
 }
 
-func GenerateRandomDomainName() string {
-	randomPrefix := randomString("abcdefghijklmnopqrstuvwxyz", 1)
-	randomName := randomString("abcdefghijklmnopqrstuvwxyz1234567890", 7)
-
-	return fmt.Sprintf("%s%s", randomPrefix, randomName)
-}
+// blockAttributePath returns the path of the attribute named by the blocks leading to it, each holding a single
+// element.
+func blockAttributePath(attribute []string) path.Path {
+	p := path.Root(attribute[0])
+	for _, name := range attribute[1:] {
+		p = p.AtListIndex(0).AtName(name)
+	}
 
-func ResourceGroupID(subscriptionId string, resourceGroupName string) string {
-	fmtString := "/subscriptions/%s/resourceGroups/%s"
-	return fmt.Sprintf(fmtString, subscriptionId, resourceGroupName)
+	return p
 }
@@ -0,0 +1,9 @@
+package parse
+
+import "fmt"
+
+// ID returns the Resource ID of the Cluster
+func (id ClusterId) ID() string {
+	fmtString := "/subscriptions/%s/resourceGroups/%s/providers/Microsoft.RedHatOpenShift/openShiftClusters/%s"
+	return fmt.Sprintf(fmtString, id.SubscriptionId, id.ResourceGroup, id.ManagedClusterName)
+}
@@ -0,0 +1,48 @@
+package parse
+
+import (
+	"fmt"
+
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/azure"
+)
+
+type ResourceGroupId struct {
+	SubscriptionId string
+	Name           string
+}
+
+func (id ResourceGroupId) String() string {
+	return fmt.Sprintf("%s: (Name %q)", "Resource Group", id.Name)
+}
+
+// ID returns the Resource ID of the Resource Group
+func (id ResourceGroupId) ID() string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", id.SubscriptionId, id.Name)
+}
+
+// ResourceGroupID parses a Resource Group ID into an ResourceGroupId struct
+func ResourceGroupID(input string) (*ResourceGroupId, error) {
+	id, err := azure.ParseAzureResourceID(input)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceId := ResourceGroupId{
+		SubscriptionId: id.SubscriptionID,
+		Name:           id.ResourceGroup,
+	}
+
+	if resourceId.SubscriptionId == "" {
+		return nil, fmt.Errorf("ID was missing the 'subscriptions' element")
+	}
+
+	if resourceId.Name == "" {
+		return nil, fmt.Errorf("ID was missing the 'resourceGroups' element")
+	}
+
+	if id.Provider != "" || len(id.Path) > 0 {
+		return nil, fmt.Errorf("ID %q is not a Resource Group ID", input)
+	}
+
+	return &resourceId, nil
+}
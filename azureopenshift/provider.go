@@ -2,12 +2,17 @@ package azureopenshift
 
 import (
 	"context"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/auth"
 	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/clients"
+	openShiftValidate "github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/validate"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/azure"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/utils"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/validate"
 )
 
 // Provider -
@@ -36,6 +41,32 @@ func Provider() *schema.Provider {
 				Description: "The Client Secret which should be used. For use When authenticating as a Service Principal using a Client Secret.",
 			},
 
+			"client_certificate_path": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("ARM_CLIENT_CERTIFICATE_PATH", ""),
+				Description:   "The path to the Client Certificate associated with the Service Principal which should be used. For use When authenticating as a Service Principal using a Client Certificate.",
+				ConflictsWith: []string{"client_certificate"},
+			},
+
+			"client_certificate": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				DefaultFunc:   schema.EnvDefaultFunc("ARM_CLIENT_CERTIFICATE", nil),
+				Description:   "The base64 encoded PFX or PEM bundle of the Client Certificate associated with the Service Principal which should be used. For use When authenticating as a Service Principal using a Client Certificate.",
+				ValidateFunc:  validation.StringIsBase64,
+				ConflictsWith: []string{"client_certificate_path"},
+			},
+
+			"client_certificate_password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_CLIENT_CERTIFICATE_PASSWORD", ""),
+				Description: "The password associated with the Client Certificate. For use When authenticating as a Service Principal using a Client Certificate.",
+			},
+
 			"tenant_id": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -53,11 +84,179 @@ func Provider() *schema.Provider {
 				Description:  "The Cloud Environment which should be used. Possible values are public and usgovernment. Defaults to public.",
 				ValidateFunc: validation.StringInSlice([]string{auth.AzurePublicString, auth.AzureUSGovernmentString}, false),
 			},
-		},
 
-		ResourcesMap: map[string]*schema.Resource{
-			"azureopenshift_redhatopenshift_cluster": resourceOpenShiftCluster(),
+			"metadata_host": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("ARM_METADATA_HOSTNAME", ""),
+				Description:   "The Hostname of the Azure Metadata Service used to discover the endpoints of a custom, sovereign or private cloud, for example `management.azure.com`.",
+				ConflictsWith: []string{"resource_manager_endpoint"},
+			},
+
+			"resource_manager_endpoint": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("ARM_RESOURCE_MANAGER_ENDPOINT", nil),
+				Description:   "The Azure Resource Manager endpoint whose metadata should be used to discover the endpoints of a custom, sovereign or private cloud, for example `https://management.local.azurestack.external/`.",
+				ValidateFunc:  validation.IsURLWithScheme([]string{"http", "https"}),
+				ConflictsWith: []string{"metadata_host"},
+			},
+
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_MAX_RETRIES", 3),
+				Description:  "The maximum number of times a failed request to Azure is retried. Set to 0 to disable retries. Defaults to 3.",
+				ValidateFunc: validation.IntBetween(0, 20),
+			},
+
+			"retry_delay": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_RETRY_DELAY", "4s"),
+				Description:  "The initial delay between retries of a failed request, which grows exponentially. Defaults to 4s.",
+				ValidateFunc: validate.Duration,
+			},
+
+			"max_retry_delay": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_MAX_RETRY_DELAY", "60s"),
+				Description:  "The maximum delay between retries of a failed request. Defaults to 60s.",
+				ValidateFunc: validate.Duration,
+			},
+
+			"request_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_REQUEST_TIMEOUT", nil),
+				Description:  "The timeout of a single attempt of a request to Azure, for example `2m`. Defaults to the SDK's timeout.",
+				ValidateFunc: validate.Duration,
+			},
+
+			"proxy_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_PROXY_URL", nil),
+				Description:  "The URL of the HTTP(S) proxy which should be used for requests to Azure. Defaults to the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables.",
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https"}),
+			},
+
+			"ca_bundle_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_CA_BUNDLE_PATH", ""),
+				Description: "The path to a PEM encoded bundle of additional CA certificates which should be trusted, for example for a corporate proxy.",
+			},
+
+			"operation_retry_attempts": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_OPERATION_RETRY_ATTEMPTS", 5),
+				Description:  "The maximum number of attempts of a cluster operation failing with a transient error, such as a conflict on a shared virtual network, throttling or an internal error. Set to 1 to disable retries. Defaults to 5.",
+				ValidateFunc: validation.IntBetween(1, 50),
+			},
+
+			"operation_retry_max_backoff": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_OPERATION_RETRY_MAX_BACKOFF", "10m"),
+				Description:  "The maximum total time spent waiting between the attempts of a cluster operation. Retries also stop once the resource timeout would be exceeded. Defaults to 10m.",
+				ValidateFunc: validate.Duration,
+			},
+
+			"max_concurrent_cluster_creates": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_MAX_CONCURRENT_CLUSTER_CREATES", 0),
+				Description:  "The maximum number of clusters created at once in the subscription. Defaults to 0, which doesn't limit concurrent creates.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"partner_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_PARTNER_ID", ""),
+				Description:  "A GUID/UUID that is registered with Microsoft to facilitate partner resource usage attribution.",
+				ValidateFunc: openShiftValidate.PartnerID,
+			},
+
+			"user_agent_suffix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_USER_AGENT_SUFFIX", ""),
+				Description: "A suffix appended to the User-Agent of every request made to Azure, used to identify automation in the activity logs.",
+			},
+
+			"auth_method": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_AUTH_METHOD", auth.AuthMethodAuto),
+				Description: "The authentication method which should be used. Possible values are auto, client_certificate, client_secret and azure_cli. Defaults to auto, which tries the configured client certificate and client secret in turn, or the Azure CLI when neither is set.",
+				ValidateFunc: validation.StringInSlice([]string{
+					auth.AuthMethodAuto,
+					auth.AuthMethodClientCertificate,
+					auth.AuthMethodClientSecret,
+					auth.AuthMethodAzureCLI,
+				}, false),
+			},
+
+			"deletion_protection_tag": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_DELETION_PROTECTION_TAG", nil),
+				Description:  "The key of a tag which prevents clusters carrying it from being deleted, whatever their configuration.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"default_tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Tags which are applied to every resource, unless overridden by the resource's own tags.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tags": {
+							Type:         schema.TypeMap,
+							Optional:     true,
+							ValidateFunc: azure.ValidateTags,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+
+			"ignore_tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Tags which are ignored when reading resources, e.g. because they are set by Azure Policy.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"keys": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+						},
+						"key_prefixes": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+						},
+					},
+				},
+			},
 		},
+
+		ResourcesMap:   map[string]*schema.Resource{},
 		DataSourcesMap: map[string]*schema.Resource{},
 	}
 	p.ConfigureContextFunc = providerConfigure(p)
@@ -77,12 +276,63 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 			ClientSecret:   d.Get("client_secret").(string),
 			ClientId:       d.Get("client_id").(string),
 			Environment:    d.Get("environment").(string),
+			AuthMethod:     d.Get("auth_method").(string),
+
+			MetadataHost:            d.Get("metadata_host").(string),
+			ResourceManagerEndpoint: d.Get("resource_manager_endpoint").(string),
+
+			MaxRetries:    int32(d.Get("max_retries").(int)),
+			RetryDelay:    parseDuration(d.Get("retry_delay").(string)),
+			MaxRetryDelay: parseDuration(d.Get("max_retry_delay").(string)),
+			TryTimeout:    parseDuration(d.Get("request_timeout").(string)),
+			ProxyURL:      d.Get("proxy_url").(string),
+			CABundlePath:  d.Get("ca_bundle_path").(string),
+
+			PartnerId:       d.Get("partner_id").(string),
+			UserAgentSuffix: d.Get("user_agent_suffix").(string),
+
+			OperationRetryAttempts:   d.Get("operation_retry_attempts").(int),
+			OperationRetryMaxBackoff: parseDuration(d.Get("operation_retry_max_backoff").(string)),
+
+			MaxConcurrentClusterCreates: d.Get("max_concurrent_cluster_creates").(int),
+
+			ClientCertificatePath:     d.Get("client_certificate_path").(string),
+			ClientCertificate:         d.Get("client_certificate").(string),
+			ClientCertificatePassword: d.Get("client_certificate_password").(string),
+			AuthMethodConfigured:      !d.GetRawConfig().GetAttr("auth_method").IsNull(),
 		}
 
-		client, err := clients.NewClient(stopCtx, config)
-		if err != nil {
-			return nil, diag.Errorf("building AzureRM Client: %s", err)
+		client, diags := clients.NewClient(ctx, stopCtx, config)
+		if diags.HasError() {
+			return nil, diags
 		}
-		return client, nil
+		client.TagsConfig = expandProviderTagsConfig(d.Get("default_tags").([]interface{}), d.Get("ignore_tags").([]interface{}))
+		client.DeletionProtectionTag = d.Get("deletion_protection_tag").(string)
+
+		return client, diags
 	}
 }
+
+func expandProviderTagsConfig(defaultTags []interface{}, ignoreTags []interface{}) azure.TagsConfig {
+	var config azure.TagsConfig
+
+	if len(defaultTags) > 0 && defaultTags[0] != nil {
+		v := defaultTags[0].(map[string]interface{})
+		config.DefaultTags = v["tags"].(map[string]interface{})
+	}
+
+	if len(ignoreTags) > 0 && ignoreTags[0] != nil {
+		v := ignoreTags[0].(map[string]interface{})
+		config.IgnoreKeys = *utils.ExpandStringSlice(v["keys"].(*schema.Set).List())
+		config.IgnoreKeyPrefixes = *utils.ExpandStringSlice(v["key_prefixes"].(*schema.Set).List())
+	}
+
+	return config
+}
+
+// parseDuration parses a duration which has already been checked by validate.Duration, treating an empty string as
+// unset.
+func parseDuration(input string) time.Duration {
+	d, _ := time.ParseDuration(input)
+	return d
+}
@@ -0,0 +1,308 @@
+package azureopenshift
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/parse"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/aro"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/azure"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/validate"
+)
+
+// providerFunctions are the functions of the provider, called as `provider::azureopenshift::<name>` from Terraform
+// 1.8 or later.
+var providerFunctions = []func() function.Function{
+	newParseClusterIdFunction,
+	newBuildClusterIdFunction,
+	newParseResourceIdFunction,
+	newConsoleURLFunction,
+	newAPIURLFunction,
+	newCIDRsOverlapFunction,
+}
+
+var clusterIdAttributeTypes = map[string]attr.Type{
+	"subscription_id":     types.StringType,
+	"resource_group_name": types.StringType,
+	"name":                types.StringType,
+}
+
+type clusterIdModel struct {
+	SubscriptionId    string `tfsdk:"subscription_id"`
+	ResourceGroupName string `tfsdk:"resource_group_name"`
+	Name              string `tfsdk:"name"`
+}
+
+type parseClusterIdFunction struct{}
+
+func newParseClusterIdFunction() function.Function {
+	return &parseClusterIdFunction{}
+}
+
+func (f *parseClusterIdFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_cluster_id"
+}
+
+func (f *parseClusterIdFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Parse the ID of a Red Hat OpenShift cluster",
+		Description: "Returns the subscription_id, resource_group_name and name of the cluster with the given ID.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "The ID of the cluster.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: clusterIdAttributeTypes,
+		},
+	}
+}
+
+func (f *parseClusterIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &input))
+	if resp.Error != nil {
+		return
+	}
+
+	id, err := parse.ClusterID(input)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, clusterIdModel{
+		SubscriptionId:    id.SubscriptionId,
+		ResourceGroupName: id.ResourceGroup,
+		Name:              id.ManagedClusterName,
+	})
+}
+
+type buildClusterIdFunction struct{}
+
+func newBuildClusterIdFunction() function.Function {
+	return &buildClusterIdFunction{}
+}
+
+func (f *buildClusterIdFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "build_cluster_id"
+}
+
+func (f *buildClusterIdFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build the ID of a Red Hat OpenShift cluster",
+		Description: "Returns the ID of the cluster with the given name in the resource group and subscription.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "subscription_id",
+				Description: "The ID of the subscription of the cluster.",
+			},
+			function.StringParameter{
+				Name:        "resource_group_name",
+				Description: "The name of the resource group of the cluster.",
+			},
+			function.StringParameter{
+				Name:        "name",
+				Description: "The name of the cluster.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *buildClusterIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var subscriptionId, resourceGroupName, name string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &subscriptionId, &resourceGroupName, &name))
+	if resp.Error != nil {
+		return
+	}
+
+	for i, v := range []string{subscriptionId, resourceGroupName, name} {
+		if v == "" {
+			resp.Error = function.NewArgumentFuncError(int64(i), "must not be empty")
+			return
+		}
+	}
+
+	resp.Error = resp.Result.Set(ctx, parse.NewClusterID(subscriptionId, resourceGroupName, name).ID())
+}
+
+type resourceIdModel struct {
+	SubscriptionId    string            `tfsdk:"subscription_id"`
+	ResourceGroupName string            `tfsdk:"resource_group_name"`
+	Provider          string            `tfsdk:"provider"`
+	Path              map[string]string `tfsdk:"path"`
+}
+
+type parseResourceIdFunction struct{}
+
+func newParseResourceIdFunction() function.Function {
+	return &parseResourceIdFunction{}
+}
+
+func (f *parseResourceIdFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_resource_id"
+}
+
+func (f *parseResourceIdFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse the ID of an Azure resource",
+		Description: "Returns the subscription_id, resource_group_name and provider of the resource with the given ID, " +
+			"along with a path mapping each resource type of the ID to its name, e.g. `virtualNetworks` and `subnets`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "The ID of the resource.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"subscription_id":     types.StringType,
+				"resource_group_name": types.StringType,
+				"provider":            types.StringType,
+				"path":                types.MapType{ElemType: types.StringType},
+			},
+		},
+	}
+}
+
+func (f *parseResourceIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &input))
+	if resp.Error != nil {
+		return
+	}
+
+	id, err := azure.ParseAzureResourceID(input)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, resourceIdModel{
+		SubscriptionId:    id.SubscriptionID,
+		ResourceGroupName: id.ResourceGroup,
+		Provider:          id.Provider,
+		Path:              id.Path,
+	})
+}
+
+// clusterURLFunction builds one of the URLs of a cluster from its domain and location.
+type clusterURLFunction struct {
+	name        string
+	summary     string
+	description string
+	build       func(domain string, location string) string
+}
+
+func newConsoleURLFunction() function.Function {
+	return &clusterURLFunction{
+		name:        "console_url",
+		summary:     "Build the URL of the web console of a Red Hat OpenShift cluster",
+		description: "Returns the URL of the web console of the cluster with the given domain in the location.",
+		build:       aro.ConsoleURL,
+	}
+}
+
+func newAPIURLFunction() function.Function {
+	return &clusterURLFunction{
+		name:        "api_url",
+		summary:     "Build the URL of the API server of a Red Hat OpenShift cluster",
+		description: "Returns the URL of the API server of the cluster with the given domain in the location.",
+		build:       aro.APIServerURL,
+	}
+}
+
+func (f *clusterURLFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = f.name
+}
+
+func (f *clusterURLFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     f.summary,
+		Description: f.description,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "domain",
+				Description: "The domain of the cluster, either managed by ARO such as `abcdefgh`, or custom such as `aro.example.com`.",
+			},
+			function.StringParameter{
+				Name:        "location",
+				Description: "The location of the cluster, used to qualify a domain managed by ARO.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *clusterURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var domain, location string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &domain, &location))
+	if resp.Error != nil {
+		return
+	}
+
+	if domain == "" {
+		resp.Error = function.NewArgumentFuncError(0, "must not be empty")
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, f.build(domain, location))
+}
+
+type cidrsOverlapFunction struct{}
+
+func newCIDRsOverlapFunction() function.Function {
+	return &cidrsOverlapFunction{}
+}
+
+func (f *cidrsOverlapFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cidrs_overlap"
+}
+
+func (f *cidrsOverlapFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Check whether two CIDR blocks overlap",
+		Description: "Returns whether the two CIDR blocks have any address in common, e.g. to validate that the pod and service CIDRs of a cluster don't overlap with its virtual network.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "a",
+				Description: "A CIDR block, such as `10.128.0.0/14`.",
+			},
+			function.StringParameter{
+				Name:        "b",
+				Description: "Another CIDR block, such as `10.0.0.0/22`.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *cidrsOverlapFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var a, b string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &a, &b))
+	if resp.Error != nil {
+		return
+	}
+
+	for i, cidr := range []string{a, b} {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			resp.Error = function.NewArgumentFuncError(int64(i), fmt.Sprintf("parsing CIDR %q: %+v", cidr, err))
+			return
+		}
+	}
+
+	overlap, err := validate.CIDRsOverlap(a, b)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, overlap)
+}
@@ -0,0 +1,159 @@
+package azureopenshift
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/fake"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/utils"
+)
+
+// runFunction calls the function as Terraform does, returning its result or error.
+func runFunction(t *testing.T, newFunction func() function.Function, arguments ...attr.Value) (attr.Value, *function.FuncError) {
+	t.Helper()
+	ctx := context.Background()
+	f := newFunction()
+
+	definition := &function.DefinitionResponse{}
+	f.Definition(ctx, function.DefinitionRequest{}, definition)
+	if definition.Diagnostics.HasError() {
+		t.Fatalf("invalid definition: %+v", definition.Diagnostics)
+	}
+
+	result, funcErr := definition.Definition.Return.NewResultData(ctx)
+	if funcErr != nil {
+		t.Fatalf("building the result: %+v", funcErr)
+	}
+
+	resp := &function.RunResponse{Result: result}
+	f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData(arguments)}, resp)
+
+	return resp.Result.Value(), resp.Error
+}
+
+func TestProviderFunctions(t *testing.T) {
+	clusterIdValue := types.ObjectValueMust(clusterIdAttributeTypes, map[string]attr.Value{
+		"subscription_id":     types.StringValue(fake.SubscriptionID),
+		"resource_group_name": types.StringValue(fake.ResourceGroupName),
+		"name":                types.StringValue(fake.ClusterName),
+	})
+	subnetId := "/subscriptions/" + fake.SubscriptionID + "/resourceGroups/network-rg/providers/Microsoft.Network/virtualNetworks/aro-vnet/subnets/master"
+	subnetIdValue := types.ObjectValueMust(map[string]attr.Type{
+		"subscription_id":     types.StringType,
+		"resource_group_name": types.StringType,
+		"provider":            types.StringType,
+		"path":                types.MapType{ElemType: types.StringType},
+	}, map[string]attr.Value{
+		"subscription_id":     types.StringValue(fake.SubscriptionID),
+		"resource_group_name": types.StringValue("network-rg"),
+		"provider":            types.StringValue("Microsoft.Network"),
+		"path": types.MapValueMust(types.StringType, map[string]attr.Value{
+			"virtualNetworks": types.StringValue("aro-vnet"),
+			"subnets":         types.StringValue("master"),
+		}),
+	})
+
+	testCases := []struct {
+		name        string
+		function    func() function.Function
+		arguments   []attr.Value
+		expected    attr.Value
+		expectError bool
+		// errorArgument is the index of the argument the error is reported for, if any
+		errorArgument *int64
+	}{
+		{
+			name:      "parse_cluster_id",
+			function:  newParseClusterIdFunction,
+			arguments: []attr.Value{types.StringValue(testClusterId)},
+			expected:  clusterIdValue,
+		},
+		{
+			name:        "parse_cluster_id of a subnet",
+			function:    newParseClusterIdFunction,
+			arguments:   []attr.Value{types.StringValue(subnetId)},
+			expectError: true,
+		},
+		{
+			name:      "build_cluster_id",
+			function:  newBuildClusterIdFunction,
+			arguments: []attr.Value{types.StringValue(fake.SubscriptionID), types.StringValue(fake.ResourceGroupName), types.StringValue(fake.ClusterName)},
+			expected:  types.StringValue(testClusterId),
+		},
+		{
+			name:        "build_cluster_id without a name",
+			function:    newBuildClusterIdFunction,
+			arguments:   []attr.Value{types.StringValue(fake.SubscriptionID), types.StringValue(fake.ResourceGroupName), types.StringValue("")},
+			expectError: true,
+		},
+		{
+			name:      "parse_resource_id",
+			function:  newParseResourceIdFunction,
+			arguments: []attr.Value{types.StringValue(subnetId)},
+			expected:  subnetIdValue,
+		},
+		{
+			name:        "parse_resource_id of an invalid ID",
+			function:    newParseResourceIdFunction,
+			arguments:   []attr.Value{types.StringValue("not-an-id")},
+			expectError: true,
+		},
+		{
+			name:      "console_url",
+			function:  newConsoleURLFunction,
+			arguments: []attr.Value{types.StringValue("abcdefgh"), types.StringValue("eastus")},
+			expected:  types.StringValue("https://console-openshift-console.apps.abcdefgh.eastus.aroapp.io/"),
+		},
+		{
+			name:      "api_url with a custom domain",
+			function:  newAPIURLFunction,
+			arguments: []attr.Value{types.StringValue("aro.example.com"), types.StringValue("eastus")},
+			expected:  types.StringValue("https://api.aro.example.com:6443/"),
+		},
+		{
+			name:      "cidrs_overlap",
+			function:  newCIDRsOverlapFunction,
+			arguments: []attr.Value{types.StringValue("10.0.0.0/16"), types.StringValue("10.0.4.0/22")},
+			expected:  types.BoolValue(true),
+		},
+		{
+			name:      "cidrs_overlap of distinct blocks",
+			function:  newCIDRsOverlapFunction,
+			arguments: []attr.Value{types.StringValue("10.128.0.0/14"), types.StringValue("172.30.0.0/16")},
+			expected:  types.BoolValue(false),
+		},
+		{
+			name:          "cidrs_overlap of an invalid block",
+			function:      newCIDRsOverlapFunction,
+			arguments:     []attr.Value{types.StringValue("10.0.0.0/16"), types.StringValue("10.0.4.0")},
+			expectError:   true,
+			errorArgument: utils.Int64(1),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, funcErr := runFunction(t, tc.function, tc.arguments...)
+
+			if tc.expectError {
+				if funcErr == nil {
+					t.Fatalf("expected an error, got %s", result)
+				}
+				if tc.errorArgument != nil && (funcErr.FunctionArgument == nil || *funcErr.FunctionArgument != *tc.errorArgument) {
+					t.Fatalf("expected an error for argument %d, got %+v", *tc.errorArgument, funcErr)
+				}
+				return
+			}
+			if funcErr != nil {
+				t.Fatalf("unexpected error: %+v", funcErr)
+			}
+			if !result.Equal(tc.expected) {
+				t.Fatalf("expected %s, got %s", tc.expected, result)
+			}
+		})
+	}
+}
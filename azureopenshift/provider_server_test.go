@@ -0,0 +1,82 @@
+package azureopenshift
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/clients"
+)
+
+func TestProviderInternalValidate(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("expected the provider to be valid: %+v", err)
+	}
+}
+
+func TestProtoV5ProviderServerFactory(t *testing.T) {
+	ctx := context.Background()
+
+	serverFactory, err := ProtoV5ProviderServerFactory(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the mux server only compares the provider schemas of its servers when asked for them
+	resp, err := serverFactory().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Fatalf("unexpected error: %s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	if _, ok := resp.ResourceSchemas["azureopenshift_redhatopenshift_cluster"]; !ok {
+		t.Fatal("expected the cluster resource to be served")
+	}
+	if _, ok := resp.EphemeralResourceSchemas["azureopenshift_redhatopenshift_cluster_credentials"]; !ok {
+		t.Fatal("expected the cluster credentials ephemeral resource to be served")
+	}
+	if _, ok := resp.ListResourceSchemas["azureopenshift_redhatopenshift_cluster"]; !ok {
+		t.Fatal("expected the cluster list resource to be served")
+	}
+	if _, ok := resp.Functions["parse_cluster_id"]; !ok {
+		t.Fatal("expected the provider functions to be served")
+	}
+
+	identityResp, err := serverFactory().GetResourceIdentitySchemas(ctx, &tfprotov5.GetResourceIdentitySchemasRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := identityResp.IdentitySchemas["azureopenshift_redhatopenshift_cluster"]; !ok {
+		t.Fatal("expected the identity of the cluster resource to be served")
+	}
+}
+
+func TestFrameworkProviderConfigure(t *testing.T) {
+	testCases := []struct {
+		meta     interface{}
+		hasError bool
+	}{
+		{meta: nil, hasError: true},
+		{meta: &clients.Client{SubscriptionID: "sub"}, hasError: false},
+	}
+
+	for _, tc := range testCases {
+		sdkProvider := Provider()
+		sdkProvider.SetMeta(tc.meta)
+
+		resp := &provider.ConfigureResponse{}
+		NewFrameworkProvider(sdkProvider).Configure(context.Background(), provider.ConfigureRequest{}, resp)
+
+		if resp.Diagnostics.HasError() != tc.hasError {
+			t.Fatalf("expected an error %t, got %+v", tc.hasError, resp.Diagnostics)
+		}
+		if !tc.hasError && resp.ResourceData != tc.meta {
+			t.Fatalf("expected the client of the SDKv2 provider to be shared, got %+v", resp.ResourceData)
+		}
+	}
+}
@@ -0,0 +1,976 @@
+package azureopenshift
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	redhatopenshift "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/redhatopenshift/armredhatopenshift"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/clients"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/parse"
+	openShiftValidate "github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/validate"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/aro"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/aroerrors"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/azure"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/locks"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/tf"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/utils"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/validate"
+)
+
+// openShiftClusterAzureRMResource is the cluster resource with the schema of `azurerm_redhat_openshift_cluster`, so
+// that modules can be moved to its shape before switching providers.  The blocks are translated to and from those of
+// `azureopenshift_redhatopenshift_cluster`, which share their expand and flatten functions with it.
+type openShiftClusterAzureRMResource struct {
+	client *clients.Client
+}
+
+var (
+	_ resource.ResourceWithConfigure   = &openShiftClusterAzureRMResource{}
+	_ resource.ResourceWithIdentity    = &openShiftClusterAzureRMResource{}
+	_ resource.ResourceWithImportState = &openShiftClusterAzureRMResource{}
+)
+
+type openShiftClusterAzureRMModel struct {
+	Id                types.String   `tfsdk:"id"`
+	Name              types.String   `tfsdk:"name"`
+	Location          types.String   `tfsdk:"location"`
+	ResourceGroupName types.String   `tfsdk:"resource_group_name"`
+	ClusterProfile    types.List     `tfsdk:"cluster_profile"`
+	ServicePrincipal  types.List     `tfsdk:"service_principal"`
+	NetworkProfile    types.List     `tfsdk:"network_profile"`
+	MainProfile       types.List     `tfsdk:"main_profile"`
+	WorkerProfile     types.List     `tfsdk:"worker_profile"`
+	ApiServerProfile  types.List     `tfsdk:"api_server_profile"`
+	IngressProfile    types.List     `tfsdk:"ingress_profile"`
+	ConsoleUrl        types.String   `tfsdk:"console_url"`
+	Tags              types.Map      `tfsdk:"tags"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+}
+
+type openShiftClusterAzureRMProfileModel struct {
+	Domain                   types.String `tfsdk:"domain"`
+	Version                  types.String `tfsdk:"version"`
+	FipsEnabled              types.Bool   `tfsdk:"fips_enabled"`
+	ManagedResourceGroupName types.String `tfsdk:"managed_resource_group_name"`
+	PullSecret               types.String `tfsdk:"pull_secret"`
+	ResourceGroupId          types.String `tfsdk:"resource_group_id"`
+}
+
+type openShiftClusterAzureRMServicePrincipalModel struct {
+	ClientId     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+}
+
+type openShiftClusterAzureRMNetworkProfileModel struct {
+	PodCidr                                  types.String `tfsdk:"pod_cidr"`
+	ServiceCidr                              types.String `tfsdk:"service_cidr"`
+	OutboundType                             types.String `tfsdk:"outbound_type"`
+	PreconfiguredNetworkSecurityGroupEnabled types.Bool   `tfsdk:"preconfigured_network_security_group_enabled"`
+}
+
+type openShiftClusterAzureRMMainProfileModel struct {
+	SubnetId                types.String `tfsdk:"subnet_id"`
+	VmSize                  types.String `tfsdk:"vm_size"`
+	EncryptionAtHostEnabled types.Bool   `tfsdk:"encryption_at_host_enabled"`
+	DiskEncryptionSetId     types.String `tfsdk:"disk_encryption_set_id"`
+}
+
+type openShiftClusterAzureRMWorkerProfileModel struct {
+	SubnetId                types.String `tfsdk:"subnet_id"`
+	VmSize                  types.String `tfsdk:"vm_size"`
+	DiskSizeGb              types.Int64  `tfsdk:"disk_size_gb"`
+	NodeCount               types.Int64  `tfsdk:"node_count"`
+	EncryptionAtHostEnabled types.Bool   `tfsdk:"encryption_at_host_enabled"`
+	DiskEncryptionSetId     types.String `tfsdk:"disk_encryption_set_id"`
+}
+
+type openShiftClusterAzureRMAPIServerProfileModel struct {
+	Visibility types.String `tfsdk:"visibility"`
+	IpAddress  types.String `tfsdk:"ip_address"`
+	Url        types.String `tfsdk:"url"`
+}
+
+type openShiftClusterAzureRMIngressProfileModel struct {
+	Visibility types.String `tfsdk:"visibility"`
+	IpAddress  types.String `tfsdk:"ip_address"`
+	Name       types.String `tfsdk:"name"`
+}
+
+func newOpenShiftClusterAzureRMResource() resource.Resource {
+	return &openShiftClusterAzureRMResource{}
+}
+
+func (r *openShiftClusterAzureRMResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_redhat_openshift_cluster"
+}
+
+func (r *openShiftClusterAzureRMResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"name": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"location": locationAttribute(),
+
+			"resource_group_name": resourceGroupNameAttribute(),
+
+			"console_url": computedStringAttribute(),
+
+			"tags": tagsAttribute(),
+		},
+
+		Blocks: map[string]schema.Block{
+			"cluster_profile": schema.ListNestedBlock{
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, 1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"domain":       requiredReplacedStringAttribute(stringvalidator.LengthAtLeast(1)),
+						"version":      requiredReplacedStringAttribute(stringvalidator.LengthAtLeast(1)),
+						"fips_enabled": replacedBoolAttribute(),
+						"managed_resource_group_name": schema.StringAttribute{
+							Optional: true,
+							Computed: true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+								stringplanmodifier.RequiresReplace(),
+							},
+						},
+						"pull_secret": schema.StringAttribute{
+							Optional:  true,
+							Sensitive: true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.RequiresReplace(),
+							},
+						},
+						"resource_group_id": computedStringAttribute(),
+					},
+				},
+			},
+
+			"service_principal": schema.ListNestedBlock{
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, 1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"client_id": schema.StringAttribute{
+							Required: true,
+							Validators: []validator.String{
+								tf.StringValidator(openShiftValidate.ClientID),
+							},
+						},
+						"client_secret": schema.StringAttribute{
+							Required:  true,
+							Sensitive: true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+					},
+				},
+			},
+
+			"network_profile": schema.ListNestedBlock{
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, 1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"pod_cidr":     requiredReplacedStringAttribute(tf.StringValidator(validate.CIDR)),
+						"service_cidr": requiredReplacedStringAttribute(tf.StringValidator(validate.CIDR)),
+						"outbound_type": schema.StringAttribute{
+							Optional: true,
+							Computed: true,
+							Default:  stringdefault.StaticString(string(redhatopenshift.OutboundTypeLoadbalancer)),
+							Validators: []validator.String{
+								tf.StringValidator(validate.ValidateOutBoundType),
+							},
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.RequiresReplace(),
+							},
+						},
+						"preconfigured_network_security_group_enabled": replacedBoolAttribute(),
+					},
+				},
+			},
+
+			"main_profile": schema.ListNestedBlock{
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, 1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"subnet_id":                  requiredReplacedStringAttribute(tf.StringValidator(azure.ValidateResourceID)),
+						"vm_size":                    requiredVMSizeAttribute(),
+						"encryption_at_host_enabled": replacedBoolAttribute(),
+						"disk_encryption_set_id":     diskEncryptionSetIDAttribute(),
+					},
+				},
+			},
+
+			"worker_profile": schema.ListNestedBlock{
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, 1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"subnet_id": requiredReplacedStringAttribute(tf.StringValidator(azure.ValidateResourceID)),
+						"vm_size":   requiredVMSizeAttribute(),
+						"disk_size_gb": schema.Int64Attribute{
+							Required: true,
+							Validators: []validator.Int64{
+								tf.Int64Validator(openShiftValidate.DiskSizeGB),
+							},
+							PlanModifiers: []planmodifier.Int64{
+								int64planmodifier.RequiresReplace(),
+							},
+						},
+						"node_count": schema.Int64Attribute{
+							Required: true,
+							Validators: []validator.Int64{
+								int64validator.Between(3, 20),
+							},
+							PlanModifiers: []planmodifier.Int64{
+								int64planmodifier.RequiresReplace(),
+							},
+						},
+						"encryption_at_host_enabled": replacedBoolAttribute(),
+						"disk_encryption_set_id":     diskEncryptionSetIDAttribute(),
+					},
+				},
+			},
+
+			"api_server_profile": schema.ListNestedBlock{
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, 1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"visibility": requiredReplacedStringAttribute(stringvalidator.OneOf(
+							string(redhatopenshift.VisibilityPrivate),
+							string(redhatopenshift.VisibilityPublic),
+						)),
+						"ip_address": computedStringAttribute(),
+						"url":        computedStringAttribute(),
+					},
+				},
+			},
+
+			"ingress_profile": schema.ListNestedBlock{
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, 1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"visibility": requiredReplacedStringAttribute(stringvalidator.OneOf(
+							string(redhatopenshift.VisibilityPrivate),
+							string(redhatopenshift.VisibilityPublic),
+						)),
+						"ip_address": computedStringAttribute(),
+						"name":       computedStringAttribute(),
+					},
+				},
+			},
+
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func requiredReplacedStringAttribute(validators ...validator.String) schema.StringAttribute {
+	return schema.StringAttribute{
+		Required:   true,
+		Validators: validators,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+func replacedBoolAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+		PlanModifiers: []planmodifier.Bool{
+			boolplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+func requiredVMSizeAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Required: true,
+		Validators: []validator.String{
+			stringvalidator.LengthAtLeast(1),
+		},
+		PlanModifiers: []planmodifier.String{
+			tf.SuppressEquivalentString(strings.EqualFold),
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+func diskEncryptionSetIDAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional: true,
+		Validators: []validator.String{
+			tf.StringValidator(azure.ValidateResourceID),
+		},
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+func (r *openShiftClusterAzureRMResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = openShiftClusterIdentitySchema()
+}
+
+func (r *openShiftClusterAzureRMResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// the provider isn't configured yet when the configuration is validated
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *clients.Client, got %T.", req.ProviderData))
+		return
+	}
+
+	r.client = client
+}
+
+func (r *openShiftClusterAzureRMResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan openShiftClusterAzureRMModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, 90*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.OpenShiftClustersClient
+	retryOptions := r.client.RetryOptions
+	ctx, cancel := context.WithTimeout(r.client.StopCtx, createTimeout)
+	defer cancel()
+	ctx, tracker := clients.WithOperationTracker(ctx)
+
+	log.Printf("[INFO] preparing arguments for Red Hat Openshift Cluster create.")
+
+	resourceGroupName := plan.ResourceGroupName.ValueString()
+	name := plan.Name.ValueString()
+
+	existing, err := clients.Retry(ctx, retryOptions, "retrieving Red Hat OpenShift Cluster", func() (redhatopenshift.OpenShiftClustersClientGetResponse, error) {
+		return client.Get(ctx, resourceGroupName, name)
+	})
+	if err != nil {
+		if !utils.ResponseWasNotFound(err) {
+			resp.Diagnostics.Append(openShiftClusterAzureRMDiagnostics(aroerrors.Diagnostics(fmt.Sprintf("checking for presence of existing Red Hat Openshift Cluster %q (Resource Group %q)", name, resourceGroupName), tracker.Wrap(err)))...)
+			return
+		}
+	}
+
+	if existing.ID != nil && *existing.ID != "" {
+		resp.Diagnostics.AddError("Resource already exists", tf.ImportAsExistsError("azureopenshift_redhat_openshift_cluster", *existing.ID).Error())
+		return
+	}
+
+	properties, diags := expandOpenShiftClusterAzureRMProperties(ctx, plan, r.client.SubscriptionID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	parameters := redhatopenshift.OpenShiftCluster{
+		Name:       &name,
+		Location:   plan.Location.ValueStringPointer(),
+		Properties: properties,
+		Tags:       azure.TagsExpand(tagsMap(ctx, plan.Tags, &resp.Diagnostics), azure.TagsConfig{}),
+	}
+
+	virtualNetworkIds, err := openShiftVirtualNetworkIDs(*properties.MasterProfile.SubnetID, *properties.WorkerProfiles[0].SubnetID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid subnet ID", err.Error())
+		return
+	}
+
+	createSemaphore := r.client.CreateSemaphore
+	if err := createSemaphore.Acquire(ctx); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("waiting to create Red Hat OpenShift Cluster %q (Resource Group %q)", name, resourceGroupName), err.Error())
+		return
+	}
+	defer createSemaphore.Release()
+
+	// ARO and ARM conflict when several clusters are created in the same virtual network at once
+	if err := locks.MultipleByID(ctx, virtualNetworkIds); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("waiting to create Red Hat OpenShift Cluster %q (Resource Group %q)", name, resourceGroupName), err.Error())
+		return
+	}
+	defer locks.UnlockMultipleByID(virtualNetworkIds)
+
+	future, err := clients.Retry(ctx, retryOptions, "creating Red Hat OpenShift Cluster", func() (clients.Poller[redhatopenshift.OpenShiftClustersClientCreateOrUpdateResponse], error) {
+		return client.BeginCreateOrUpdate(ctx, resourceGroupName, name, parameters)
+	})
+	if err != nil {
+		resp.Diagnostics.Append(openShiftClusterAzureRMDiagnostics(aroerrors.Diagnostics(fmt.Sprintf("creating Red Hat OpenShift Cluster %q (Resource Group %q)", name, resourceGroupName), tracker.Wrap(err)))...)
+		return
+	}
+
+	// the cluster exists from here on, so it's kept in state even when the rest of its creation fails
+	plan.Id = types.StringValue(parse.NewClusterID(r.client.SubscriptionID, resourceGroupName, name).ID())
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), plan.Id)...)
+
+	if _, err = future.PollUntilDone(ctx, nil); err != nil {
+		resp.Diagnostics.Append(openShiftClusterAzureRMDiagnostics(aroerrors.Diagnostics(fmt.Sprintf("waiting for creation of Red Hat OpenShift Cluster %q (Resource Group %q)", name, resourceGroupName), tracker.Wrap(err)))...)
+		return
+	}
+
+	found, diags := r.read(ctx, &plan, resp.Identity)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError(fmt.Sprintf("retrieving Red Hat OpenShift Cluster %q (Resource Group %q)", name, resourceGroupName), "The cluster was not found after its creation.")
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *openShiftClusterAzureRMResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state openShiftClusterAzureRMModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 90*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.OpenShiftClustersClient
+	retryOptions := r.client.RetryOptions
+	ctx, cancel := context.WithTimeout(r.client.StopCtx, updateTimeout)
+	defer cancel()
+	ctx, tracker := clients.WithOperationTracker(ctx)
+
+	log.Printf("[INFO] preparing arguments for Red Hat OpenShift Cluster update.")
+
+	id, err := parse.ClusterID(state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid cluster ID", err.Error())
+		return
+	}
+
+	var parameters redhatopenshift.OpenShiftClusterUpdate
+	updated := false
+
+	if !plan.Tags.Equal(state.Tags) {
+		existing, err := clients.Retry(ctx, retryOptions, "retrieving Red Hat OpenShift Cluster", func() (redhatopenshift.OpenShiftClustersClientGetResponse, error) {
+			return client.Get(ctx, id.ResourceGroup, id.ManagedClusterName)
+		})
+		if err != nil {
+			resp.Diagnostics.Append(openShiftClusterAzureRMDiagnostics(aroerrors.Diagnostics(fmt.Sprintf("retrieving existing Red Hat OpenShift Cluster %q (Resource Group %q)", id.ManagedClusterName, id.ResourceGroup), tracker.Wrap(err)))...)
+			return
+		}
+
+		// keep the ignored tags, such as those added by Azure Policy, as the update replaces all of the cluster's tags
+		tagsConfig := r.client.TagsConfig
+		tags := azure.TagsExpand(tagsMap(ctx, plan.Tags, &resp.Diagnostics), azure.TagsConfig{})
+		for k, v := range existing.Tags {
+			if _, ok := tags[k]; !ok && tagsConfig.Ignored(k) {
+				tags[k] = v
+			}
+		}
+
+		parameters.Tags = tags
+		updated = true
+	}
+
+	if !plan.ServicePrincipal.Equal(state.ServicePrincipal) {
+		if sp := firstBlock[openShiftClusterAzureRMServicePrincipalModel](ctx, plan.ServicePrincipal, &resp.Diagnostics); sp != nil {
+			parameters.Properties = &redhatopenshift.OpenShiftClusterProperties{
+				ServicePrincipalProfile: expandOpenshiftServicePrincipalProfile(sp.ClientId.ValueString(), sp.ClientSecret.ValueString()),
+			}
+			updated = true
+		}
+	}
+
+	// nothing is sent when only the timeouts changed
+	if updated {
+		future, err := clients.Retry(ctx, retryOptions, "updating Red Hat OpenShift Cluster", func() (clients.Poller[redhatopenshift.OpenShiftClustersClientUpdateResponse], error) {
+			return client.BeginUpdate(ctx, id.ResourceGroup, id.ManagedClusterName, parameters)
+		})
+		if err != nil {
+			resp.Diagnostics.Append(openShiftClusterAzureRMDiagnostics(aroerrors.Diagnostics(fmt.Sprintf("updating Red Hat OpenShift Cluster %q (Resource Group %q)", id.ManagedClusterName, id.ResourceGroup), tracker.Wrap(err)))...)
+			return
+		}
+
+		if _, err = future.PollUntilDone(ctx, nil); err != nil {
+			resp.Diagnostics.Append(openShiftClusterAzureRMDiagnostics(aroerrors.Diagnostics(fmt.Sprintf("waiting for update of Red Hat OpenShift Cluster %q (Resource Group %q)", id.ManagedClusterName, id.ResourceGroup), tracker.Wrap(err)))...)
+			return
+		}
+	}
+
+	found, diags := r.read(ctx, &plan, resp.Identity)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError(fmt.Sprintf("retrieving Red Hat OpenShift Cluster %q (Resource Group %q)", id.ManagedClusterName, id.ResourceGroup), "The cluster was not found after its update.")
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *openShiftClusterAzureRMResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state openShiftClusterAzureRMModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.client.StopCtx, readTimeout)
+	defer cancel()
+
+	found, diags := r.read(ctx, &state, resp.Identity)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// read refreshes the model from the cluster, returning false when the cluster no longer exists.
+func (r *openShiftClusterAzureRMResource) read(ctx context.Context, model *openShiftClusterAzureRMModel, identity *tfsdk.ResourceIdentity) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	client := r.client.OpenShiftClustersClient
+	retryOptions := r.client.RetryOptions
+	ctx, tracker := clients.WithOperationTracker(ctx)
+
+	id, err := parse.ClusterID(model.Id.ValueString())
+	if err != nil {
+		diags.AddError("Invalid cluster ID", err.Error())
+		return true, diags
+	}
+
+	// the identity is set even when the cluster is gone, as Terraform expects one from every read
+	diags.Append(setOpenShiftClusterIdentity(ctx, identity, *id)...)
+
+	resp, err := clients.Retry(ctx, retryOptions, "retrieving Red Hat OpenShift Cluster", func() (redhatopenshift.OpenShiftClustersClientGetResponse, error) {
+		return client.Get(ctx, id.ResourceGroup, id.ManagedClusterName)
+	})
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			log.Printf("[DEBUG] Red Hat OpenShift Cluster %q was not found in Resource Group %q - removing from state!", id.ManagedClusterName, id.ResourceGroup)
+			return false, nil
+		}
+		diags.Append(openShiftClusterAzureRMDiagnostics(aroerrors.Diagnostics(fmt.Sprintf("retrieving Red Hat Openshift Cluster %q (Resource Group %q)", id.ManagedClusterName, id.ResourceGroup), tracker.Wrap(err)))...)
+		return true, diags
+	}
+
+	diags.Append(model.flatten(ctx, *id, resp.OpenShiftCluster, r.client.TagsConfig)...)
+
+	return true, diags
+}
+
+// flatten sets the attributes read from the cluster, keeping the secrets, which the API doesn't return, from the model.
+func (m *openShiftClusterAzureRMModel) flatten(ctx context.Context, id parse.ClusterId, cluster redhatopenshift.OpenShiftCluster, tagsConfig azure.TagsConfig) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.Id = types.StringValue(id.ID())
+	m.Name = stringValue(cluster.Name)
+	m.ResourceGroupName = types.StringValue(id.ResourceGroup)
+	m.Location = locationValue(m.Location, cluster.Location)
+
+	if props := cluster.Properties; props != nil {
+		clusterProfile := flattenOpenShiftClusterAzureRMProfile(props.ClusterProfile, firstBlock[openShiftClusterAzureRMProfileModel](ctx, m.ClusterProfile, &diags))
+		m.ClusterProfile = blockValue(ctx, m.ClusterProfile.ElementType(ctx), clusterProfile, &diags)
+
+		var servicePrincipal *openShiftClusterAzureRMServicePrincipalModel
+		if profile := props.ServicePrincipalProfile; profile != nil {
+			servicePrincipal = &openShiftClusterAzureRMServicePrincipalModel{
+				ClientId:     stringValue(profile.ClientID),
+				ClientSecret: types.StringNull(),
+			}
+			if prior := firstBlock[openShiftClusterAzureRMServicePrincipalModel](ctx, m.ServicePrincipal, &diags); prior != nil {
+				servicePrincipal.ClientSecret = prior.ClientSecret
+			}
+		}
+		m.ServicePrincipal = blockValue(ctx, m.ServicePrincipal.ElementType(ctx), servicePrincipal, &diags)
+
+		var networkProfile *openShiftClusterAzureRMNetworkProfileModel
+		if profile := flattenOpenShiftNetworkProfile(props.NetworkProfile); profile != nil {
+			preconfiguredNSG := props.NetworkProfile.PreconfiguredNSG
+			networkProfile = &openShiftClusterAzureRMNetworkProfileModel{
+				PodCidr:                                  profile.PodCidr,
+				ServiceCidr:                              profile.ServiceCidr,
+				OutboundType:                             profile.OutboundType,
+				PreconfiguredNetworkSecurityGroupEnabled: types.BoolValue(preconfiguredNSG != nil && *preconfiguredNSG == redhatopenshift.PreconfiguredNSGEnabled),
+			}
+		}
+		m.NetworkProfile = blockValue(ctx, m.NetworkProfile.ElementType(ctx), networkProfile, &diags)
+
+		var mainProfile *openShiftClusterAzureRMMainProfileModel
+		if profile := flattenOpenShiftMasterProfile(props.MasterProfile); profile != nil {
+			mainProfile = &openShiftClusterAzureRMMainProfileModel{
+				SubnetId:                profile.SubnetId,
+				VmSize:                  profile.VmSize,
+				EncryptionAtHostEnabled: encryptionAtHostEnabled(profile.EncryptionAtHost),
+				DiskEncryptionSetId:     optionalString(profile.DiskEncryptionSet),
+			}
+		}
+		m.MainProfile = blockValue(ctx, m.MainProfile.ElementType(ctx), mainProfile, &diags)
+
+		var workerProfile *openShiftClusterAzureRMWorkerProfileModel
+		if profile := flattenOpenShiftWorkerProfiles(props.WorkerProfiles); profile != nil {
+			workerProfile = &openShiftClusterAzureRMWorkerProfileModel{
+				SubnetId:                profile.SubnetId,
+				VmSize:                  profile.VmSize,
+				DiskSizeGb:              profile.DiskSizeGb,
+				NodeCount:               profile.NodeCount,
+				EncryptionAtHostEnabled: encryptionAtHostEnabled(profile.EncryptionAtHost),
+				DiskEncryptionSetId:     optionalString(profile.DiskEncryptionSet),
+			}
+		}
+		m.WorkerProfile = blockValue(ctx, m.WorkerProfile.ElementType(ctx), workerProfile, &diags)
+
+		var apiServerProfile *openShiftClusterAzureRMAPIServerProfileModel
+		if profile := flattenOpenShiftAPIServerProfile(props.ApiserverProfile); profile != nil {
+			apiServerProfile = &openShiftClusterAzureRMAPIServerProfileModel{
+				Visibility: profile.Visibility,
+				IpAddress:  profile.Ip,
+				Url:        profile.Url,
+			}
+		}
+		m.ApiServerProfile = blockValue(ctx, m.ApiServerProfile.ElementType(ctx), apiServerProfile, &diags)
+
+		var ingressProfile *openShiftClusterAzureRMIngressProfileModel
+		if profile := flattenOpenShiftIngressProfiles(props.IngressProfiles); profile != nil {
+			ingressProfile = &openShiftClusterAzureRMIngressProfileModel{
+				Visibility: profile.Visibility,
+				IpAddress:  profile.Ip,
+				Name:       stringValue(props.IngressProfiles[0].Name),
+			}
+		}
+		m.IngressProfile = blockValue(ctx, m.IngressProfile.ElementType(ctx), ingressProfile, &diags)
+
+		if props.ConsoleProfile != nil {
+			m.ConsoleUrl = stringValue(props.ConsoleProfile.URL)
+		}
+	}
+
+	if m.ConsoleUrl.IsUnknown() {
+		m.ConsoleUrl = types.StringNull()
+	}
+
+	// the provider's default tags aren't applied to this resource, which has no `tags_all`, but its ignored tags are
+	tags := azure.Flatten(cluster.Tags)
+	for k := range tags {
+		if tagsConfig.Ignored(k) {
+			delete(tags, k)
+		}
+	}
+	if !m.Tags.IsNull() || len(tags) > 0 {
+		m.Tags = tagsValue(ctx, tags, &diags)
+	}
+
+	return diags
+}
+
+func (r *openShiftClusterAzureRMResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state openShiftClusterAzureRMModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 90*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.OpenShiftClustersClient
+	retryOptions := r.client.RetryOptions
+	ctx, cancel := context.WithTimeout(r.client.StopCtx, deleteTimeout)
+	defer cancel()
+	ctx, tracker := clients.WithOperationTracker(ctx)
+
+	id, err := parse.ClusterID(state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid cluster ID", err.Error())
+		return
+	}
+
+	var subnetIds []string
+	if profile := firstBlock[openShiftClusterAzureRMMainProfileModel](ctx, state.MainProfile, &resp.Diagnostics); profile != nil {
+		subnetIds = append(subnetIds, profile.SubnetId.ValueString())
+	}
+	if profile := firstBlock[openShiftClusterAzureRMWorkerProfileModel](ctx, state.WorkerProfile, &resp.Diagnostics); profile != nil {
+		subnetIds = append(subnetIds, profile.SubnetId.ValueString())
+	}
+	virtualNetworkIds, err := openShiftVirtualNetworkIDs(subnetIds...)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid subnet ID", err.Error())
+		return
+	}
+
+	exists, diags := checkDeletionProtectionTag(ctx, r.client, *id, tracker)
+	resp.Diagnostics.Append(diags...)
+	if !exists || resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := locks.MultipleByID(ctx, virtualNetworkIds); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("waiting to delete Red Hat OpenShift Cluster %q (Resource Group %q)", id.ManagedClusterName, id.ResourceGroup), err.Error())
+		return
+	}
+	defer locks.UnlockMultipleByID(virtualNetworkIds)
+
+	future, err := clients.Retry(ctx, retryOptions, "deleting Red Hat OpenShift Cluster", func() (clients.Poller[redhatopenshift.OpenShiftClustersClientDeleteResponse], error) {
+		return client.BeginDelete(ctx, id.ResourceGroup, id.ManagedClusterName)
+	})
+	if err != nil {
+		resp.Diagnostics.Append(openShiftClusterAzureRMDiagnostics(aroerrors.Diagnostics(fmt.Sprintf("deleting Red Hat Openshift Cluster %q (Resource Group %q)", id.ManagedClusterName, id.ResourceGroup), tracker.Wrap(err)))...)
+		return
+	}
+
+	if _, err := future.PollUntilDone(ctx, nil); err != nil {
+		resp.Diagnostics.Append(openShiftClusterAzureRMDiagnostics(aroerrors.Diagnostics(fmt.Sprintf("waiting for the deletion of Red Hat Openshift Cluster %q (Resource Group %q)", id.ManagedClusterName, id.ResourceGroup), tracker.Wrap(err)))...)
+	}
+}
+
+func (r *openShiftClusterAzureRMResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importOpenShiftCluster(ctx, r.client, req, resp)
+}
+
+// expandOpenShiftClusterAzureRMProperties translates the blocks to those of `azureopenshift_redhatopenshift_cluster`
+// and expands them.
+func expandOpenShiftClusterAzureRMProperties(ctx context.Context, plan openShiftClusterAzureRMModel, subscriptionId string) (*redhatopenshift.OpenShiftClusterProperties, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	clusterProfile := firstBlock[openShiftClusterAzureRMProfileModel](ctx, plan.ClusterProfile, &diags)
+	servicePrincipal := firstBlock[openShiftClusterAzureRMServicePrincipalModel](ctx, plan.ServicePrincipal, &diags)
+	networkProfile := firstBlock[openShiftClusterAzureRMNetworkProfileModel](ctx, plan.NetworkProfile, &diags)
+	mainProfile := firstBlock[openShiftClusterAzureRMMainProfileModel](ctx, plan.MainProfile, &diags)
+	workerProfile := firstBlock[openShiftClusterAzureRMWorkerProfileModel](ctx, plan.WorkerProfile, &diags)
+	apiServerProfile := firstBlock[openShiftClusterAzureRMAPIServerProfileModel](ctx, plan.ApiServerProfile, &diags)
+	ingressProfile := firstBlock[openShiftClusterAzureRMIngressProfileModel](ctx, plan.IngressProfile, &diags)
+	if diags.HasError() {
+		return nil, diags
+	}
+	if clusterProfile == nil || servicePrincipal == nil || networkProfile == nil || mainProfile == nil || workerProfile == nil || apiServerProfile == nil || ingressProfile == nil {
+		diags.AddError("Invalid configuration", "All of the profiles of the cluster are required.")
+		return nil, diags
+	}
+
+	domain := clusterProfile.Domain.ValueString()
+
+	managedResourceGroupName := clusterProfile.ManagedResourceGroupName.ValueString()
+	if managedResourceGroupName == "" {
+		// the name azurerm generates
+		managedResourceGroupName = fmt.Sprintf("aro-%s", domain)
+	}
+
+	fipsValidatedModules := redhatopenshift.FipsValidatedModulesDisabled
+	if clusterProfile.FipsEnabled.ValueBool() {
+		fipsValidatedModules = redhatopenshift.FipsValidatedModulesEnabled
+	}
+
+	expandedClusterProfile := aro.NewClusterProfileHelper(subscriptionId, managedResourceGroupName, "", "").Expand((&openShiftClusterProfileModel{
+		PullSecret:           clusterProfile.PullSecret,
+		Domain:               clusterProfile.Domain,
+		Version:              clusterProfile.Version,
+		ResourceGroupId:      types.StringValue(""),
+		FipsValidatedModules: types.StringValue(string(fipsValidatedModules)),
+	}).raw())
+
+	expandedNetworkProfile := expandOpenshiftNetworkProfile(&openShiftNetworkProfileModel{
+		PodCidr:      networkProfile.PodCidr,
+		ServiceCidr:  networkProfile.ServiceCidr,
+		OutboundType: networkProfile.OutboundType,
+	})
+	preconfiguredNSG := redhatopenshift.PreconfiguredNSGDisabled
+	if networkProfile.PreconfiguredNetworkSecurityGroupEnabled.ValueBool() {
+		preconfiguredNSG = redhatopenshift.PreconfiguredNSGEnabled
+	}
+	expandedNetworkProfile.PreconfiguredNSG = to.Ptr(preconfiguredNSG)
+
+	properties := &redhatopenshift.OpenShiftClusterProperties{
+		ClusterProfile:          expandedClusterProfile,
+		ConsoleProfile:          &redhatopenshift.ConsoleProfile{},
+		ServicePrincipalProfile: expandOpenshiftServicePrincipalProfile(servicePrincipal.ClientId.ValueString(), servicePrincipal.ClientSecret.ValueString()),
+		NetworkProfile:          expandedNetworkProfile,
+		MasterProfile: expandOpenshiftMasterProfile(&openShiftMasterProfileModel{
+			SubnetId:          mainProfile.SubnetId,
+			VmSize:            mainProfile.VmSize,
+			EncryptionAtHost:  encryptionAtHost(mainProfile.EncryptionAtHostEnabled),
+			DiskEncryptionSet: optionalString(mainProfile.DiskEncryptionSetId),
+		}),
+		WorkerProfiles: expandOpenshiftWorkerProfiles(&openShiftWorkerProfileModel{
+			SubnetId:          workerProfile.SubnetId,
+			VmSize:            workerProfile.VmSize,
+			DiskSizeGb:        workerProfile.DiskSizeGb,
+			NodeCount:         workerProfile.NodeCount,
+			EncryptionAtHost:  encryptionAtHost(workerProfile.EncryptionAtHostEnabled),
+			DiskEncryptionSet: optionalString(workerProfile.DiskEncryptionSetId),
+		}),
+		ApiserverProfile: expandOpenshiftApiServerProfile(&openShiftAPIServerProfileModel{
+			Visibility: apiServerProfile.Visibility,
+		}),
+		IngressProfiles: expandOpenshiftIngressProfiles(&openShiftIngressProfileModel{
+			Visibility: ingressProfile.Visibility,
+		}),
+	}
+
+	return properties, diags
+}
+
+// flattenOpenShiftClusterAzureRMProfile translates the flattened `cluster_profile` of
+// `azureopenshift_redhatopenshift_cluster`, whose version is read from the API unlike its pull secret.
+func flattenOpenShiftClusterAzureRMProfile(profile *redhatopenshift.ClusterProfile, prior *openShiftClusterAzureRMProfileModel) *openShiftClusterAzureRMProfileModel {
+	var priorProfile *openShiftClusterProfileModel
+	if prior != nil {
+		priorProfile = &openShiftClusterProfileModel{
+			PullSecret: prior.PullSecret,
+			Version:    prior.Version,
+		}
+	}
+
+	flattened := flattenOpenShiftClusterProfile(profile, priorProfile)
+	if flattened == nil {
+		return nil
+	}
+
+	if profile.Version != nil {
+		flattened.Version = types.StringValue(*profile.Version)
+	}
+
+	managedResourceGroupName := ""
+	if resourceGroupId := flattened.ResourceGroupId.ValueString(); resourceGroupId != "" {
+		if id, err := parse.ResourceGroupID(resourceGroupId); err == nil {
+			managedResourceGroupName = id.Name
+		}
+	}
+
+	return &openShiftClusterAzureRMProfileModel{
+		Domain:                   flattened.Domain,
+		Version:                  flattened.Version,
+		FipsEnabled:              types.BoolValue(profile.FipsValidatedModules != nil && *profile.FipsValidatedModules == redhatopenshift.FipsValidatedModulesEnabled),
+		ManagedResourceGroupName: types.StringValue(managedResourceGroupName),
+		PullSecret:               flattened.PullSecret,
+		ResourceGroupId:          flattened.ResourceGroupId,
+	}
+}
+
+// encryptionAtHost translates `encryption_at_host_enabled` to the `encryption_at_host` of
+// `azureopenshift_redhatopenshift_cluster`.
+func encryptionAtHost(enabled types.Bool) types.String {
+	if enabled.ValueBool() {
+		return types.StringValue(string(redhatopenshift.EncryptionAtHostEnabled))
+	}
+
+	return types.StringValue(string(redhatopenshift.EncryptionAtHostDisabled))
+}
+
+// encryptionAtHostEnabled translates the flattened `encryption_at_host` of `azureopenshift_redhatopenshift_cluster` to
+// `encryption_at_host_enabled`.
+func encryptionAtHostEnabled(encryptionAtHost types.String) types.Bool {
+	return types.BoolValue(encryptionAtHost.ValueString() == string(redhatopenshift.EncryptionAtHostEnabled))
+}
+
+// openShiftClusterAzureRMAttributes maps the attributes of the native resource which the errors of the API are about to
+// those of the alias.
+var openShiftClusterAzureRMAttributes = map[string][]string{
+	"cluster_profile":        {"cluster_profile"},
+	"cluster_resource_group": {"cluster_profile", "managed_resource_group_name"},
+	"master_profile":         {"main_profile"},
+	"service_principal":      {"service_principal"},
+	"worker_profile":         {"worker_profile"},
+}
+
+// openShiftClusterAzureRMDiagnostics converts the diagnostics of the API errors, pointing them at the attributes of the
+// alias, or at none when the alias has no equivalent.
+func openShiftClusterAzureRMDiagnostics(diags []aroerrors.Diagnostic) diag.Diagnostics {
+	return apiErrorDiagnostics(diags, func(attribute []string) (path.Path, bool) {
+		prefix, ok := openShiftClusterAzureRMAttributes[attribute[0]]
+		if !ok {
+			return path.Empty(), false
+		}
+
+		return blockAttributePath(append(append([]string{}, prefix...), attribute[1:]...)), true
+	})
+}
@@ -0,0 +1,221 @@
+package azureopenshift
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	redhatopenshift "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/redhatopenshift/armredhatopenshift"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/fake"
+)
+
+const testClusterAzureRMResourceName = "azureopenshift_redhat_openshift_cluster.test"
+
+// testClusterAzureRMConfig returns the configuration of the cluster in the shape of `azurerm_redhat_openshift_cluster`,
+// with the provider pointed at the fake.
+func testClusterAzureRMConfig(server *testFakeServer, location string, clientSecret string, env string) string {
+	return server.ProviderConfig(server.caBundlePath) + fmt.Sprintf(`
+resource "azureopenshift_redhat_openshift_cluster" "test" {
+  name                = %q
+  location            = %q
+  resource_group_name = %q
+
+  cluster_profile {
+    domain  = %q
+    version = "4.14.16"
+  }
+
+  network_profile {
+    pod_cidr     = "10.128.0.0/14"
+    service_cidr = "172.30.0.0/16"
+
+    preconfigured_network_security_group_enabled = true
+  }
+
+  main_profile {
+    subnet_id                  = %q
+    vm_size                    = "Standard_D8s_v3"
+    encryption_at_host_enabled = true
+  }
+
+  worker_profile {
+    subnet_id    = %q
+    vm_size      = "Standard_D4s_v3"
+    disk_size_gb = 128
+    node_count   = 3
+  }
+
+  api_server_profile {
+    visibility = "Public"
+  }
+
+  ingress_profile {
+    visibility = "Public"
+  }
+
+  service_principal {
+    client_id     = %q
+    client_secret = %q
+  }
+
+  tags = {
+    env = %q
+  }
+}
+`, fake.ClusterName, location, fake.ResourceGroupName, fake.ClusterDomain, fake.SubnetID("master"), fake.SubnetID("worker"), fake.ClientID, clientSecret, env)
+}
+
+func TestResourceOpenShiftClusterAzureRM(t *testing.T) {
+	testSkipWithoutTerraform(t)
+
+	server := newTestFakeServer(t, fake.Options{})
+
+	checks := []resource.TestCheckFunc{
+		resource.TestCheckResourceAttr(testClusterAzureRMResourceName, "id", testClusterId),
+		testCheckOpenShiftCluster(server, func(cluster redhatopenshift.OpenShiftCluster) error {
+			props := cluster.Properties
+			if rg := *props.ClusterProfile.ResourceGroupID; !strings.HasSuffix(rg, "/resourceGroups/aro-"+fake.ClusterDomain) {
+				return fmt.Errorf("expected the managed resource group of azurerm, got %q", rg)
+			}
+			if v := *props.MasterProfile.EncryptionAtHost; v != redhatopenshift.EncryptionAtHostEnabled {
+				return fmt.Errorf("expected encryption at host on the main nodes, got %q", v)
+			}
+			if v := *props.WorkerProfiles[0].EncryptionAtHost; v != redhatopenshift.EncryptionAtHostDisabled {
+				return fmt.Errorf("expected no encryption at host on the worker nodes, got %q", v)
+			}
+			if props.MasterProfile.DiskEncryptionSetID != nil {
+				return fmt.Errorf("expected no disk encryption set, got %q", *props.MasterProfile.DiskEncryptionSetID)
+			}
+			if v := *props.NetworkProfile.PreconfiguredNSG; v != redhatopenshift.PreconfiguredNSGEnabled {
+				return fmt.Errorf("expected a preconfigured network security group, got %q", v)
+			}
+			if v := *props.WorkerProfiles[0].DiskSizeGB; v != 128 {
+				return fmt.Errorf("expected worker disks of 128 GB, got %d", v)
+			}
+			return nil
+		}),
+	}
+	for k, expected := range map[string]string{
+		"cluster_profile.0.managed_resource_group_name":                  "aro-" + fake.ClusterDomain,
+		"cluster_profile.0.version":                                      "4.14.16",
+		"cluster_profile.0.fips_enabled":                                 "false",
+		"main_profile.0.encryption_at_host_enabled":                      "true",
+		"main_profile.0.vm_size":                                         "Standard_D8s_v3",
+		"worker_profile.0.node_count":                                    "3",
+		"network_profile.0.preconfigured_network_security_group_enabled": "true",
+		"network_profile.0.outbound_type":                                "Loadbalancer",
+		"service_principal.0.client_secret":                              fake.ClusterSecret,
+		"ingress_profile.0.name":                                         "default",
+		"tags.env":                                                       "dev",
+		// the location is kept as configured, rather than as ARM normalizes it
+		"location": "East US",
+	} {
+		checks = append(checks, resource.TestCheckResourceAttr(testClusterAzureRMResourceName, k, expected))
+	}
+	for _, k := range []string{"api_server_profile.0.url", "api_server_profile.0.ip_address", "ingress_profile.0.ip_address", "console_url"} {
+		checks = append(checks, resource.TestCheckResourceAttrSet(testClusterAzureRMResourceName, k))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testCheckOpenShiftClusterDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testClusterAzureRMConfig(server, "East US", fake.ClusterSecret, "dev"),
+				Check:  resource.ComposeTestCheckFunc(checks...),
+			},
+			{
+				// the service principal and tags are updated in place
+				Config: testClusterAzureRMConfig(server, "East US", "rotated-secret", "prod"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpenShiftCluster(server, func(cluster redhatopenshift.OpenShiftCluster) error {
+						if v := *cluster.Properties.ServicePrincipalProfile.ClientSecret; v != "rotated-secret" {
+							return fmt.Errorf("expected the client secret to be rotated, got %q", v)
+						}
+						if v := *cluster.Tags["env"]; v != "prod" {
+							return fmt.Errorf("expected the `env` tag to be %q, got %q", "prod", v)
+						}
+						return nil
+					}),
+					func(*terraform.State) error {
+						for _, request := range server.Requests() {
+							if strings.HasPrefix(request, "DELETE ") {
+								return fmt.Errorf("expected the cluster to be updated in place, got %s", request)
+							}
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestResourceOpenShiftClusterAzureRMErrors(t *testing.T) {
+	// the errors point at the attribute causing them, or at the block of the computed ones
+	testCases := []struct {
+		code      string
+		expectErr *regexp.Regexp
+	}{
+		{
+			code:      "InvalidLinkedVNet",
+			expectErr: regexp.MustCompile(`(?s)the virtual network is not valid for the cluster.*\d+:\s+subnet_id\s+= ".*/subnets/master"`),
+		},
+		{
+			code:      "InvalidServicePrincipalCredentials",
+			expectErr: regexp.MustCompile(`(?s)the service principal credentials are invalid.*\d+:\s+client_secret\s+=`),
+		},
+		{
+			code:      "DuplicateResourceGroup",
+			expectErr: regexp.MustCompile(`(?s)the cluster resource group already exists.*\d+:\s+cluster_profile \{`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.code, func(t *testing.T) {
+			testSkipWithoutTerraform(t)
+
+			server := newTestFakeServer(t, fake.Options{})
+			server.InjectFailure(fake.Failure{Operation: fake.OperationCreateOrUpdate, StatusCode: 400, Code: tc.code})
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:      testClusterAzureRMConfig(server, "eastus", fake.ClusterSecret, "dev"),
+						ExpectError: tc.expectErr,
+					},
+				},
+			})
+		})
+	}
+}
+
+// TestResourceOpenShiftClusterAzureRMFailedCreate checks that a cluster whose creation fails once it's accepted is
+// kept in state, so that the next apply replaces it.
+func TestResourceOpenShiftClusterAzureRMFailedCreate(t *testing.T) {
+	testSkipWithoutTerraform(t)
+
+	server := newTestFakeServer(t, fake.Options{})
+	server.InjectFailure(fake.Failure{Operation: fake.OperationCreateOrUpdate, Code: "ResourceQuotaExceeded", Async: true})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testCheckOpenShiftClusterDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config:      testClusterAzureRMConfig(server, "eastus", fake.ClusterSecret, "dev"),
+				ExpectError: regexp.MustCompile(`waiting\s+for\s+creation`),
+			},
+			{
+				RefreshState:       true,
+				Check:              resource.TestCheckResourceAttr(testClusterAzureRMResourceName, "id", testClusterId),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,184 @@
+package azureopenshift
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	redhatopenshift "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/redhatopenshift/armredhatopenshift"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/clients"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/parse"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/aro"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/aroerrors"
+)
+
+// openShiftClusterCredentialsEphemeralResource lists the kubeadmin credentials and the admin kubeconfig of a cluster
+// for the duration of a plan or apply, without storing them in the state or plan files.
+type openShiftClusterCredentialsEphemeralResource struct {
+	client *clients.Client
+}
+
+var _ ephemeral.EphemeralResourceWithConfigure = &openShiftClusterCredentialsEphemeralResource{}
+
+func newOpenShiftClusterCredentialsEphemeralResource() ephemeral.EphemeralResource {
+	return &openShiftClusterCredentialsEphemeralResource{}
+}
+
+type openShiftClusterCredentialsModel struct {
+	ClusterId            types.String `tfsdk:"cluster_id"`
+	KubeadminUsername    types.String `tfsdk:"kubeadmin_username"`
+	KubeadminPassword    types.String `tfsdk:"kubeadmin_password"`
+	Kubeconfig           types.String `tfsdk:"kubeconfig"`
+	Host                 types.String `tfsdk:"host"`
+	ClusterCACertificate types.String `tfsdk:"cluster_ca_certificate"`
+	Insecure             types.Bool   `tfsdk:"insecure"`
+	ClientCertificate    types.String `tfsdk:"client_certificate"`
+	ClientKey            types.String `tfsdk:"client_key"`
+	Token                types.String `tfsdk:"token"`
+}
+
+func (r *openShiftClusterCredentialsEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_redhatopenshift_cluster_credentials"
+}
+
+func (r *openShiftClusterCredentialsEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The kubeadmin credentials and the admin kubeconfig of a Red Hat OpenShift cluster, which are never stored in the state or plan files.",
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the cluster.",
+			},
+			"kubeadmin_username": schema.StringAttribute{
+				Computed:    true,
+				Description: "The username of the kubeadmin user.",
+			},
+			"kubeadmin_password": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The password of the kubeadmin user.",
+			},
+			"kubeconfig": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The admin kubeconfig of the cluster.",
+			},
+			"host": schema.StringAttribute{
+				Computed:    true,
+				Description: "The URL of the API server, from the admin kubeconfig.",
+			},
+			"cluster_ca_certificate": schema.StringAttribute{
+				Computed:    true,
+				Description: "The PEM encoded certificate authority of the API server, from the admin kubeconfig. Empty when the API server certificate is publicly trusted.",
+			},
+			"insecure": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the admin kubeconfig skips the verification of the API server certificate.",
+			},
+			"client_certificate": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The PEM encoded client certificate of the admin user, from the admin kubeconfig.",
+			},
+			"client_key": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The PEM encoded client key of the admin user, from the admin kubeconfig.",
+			},
+			"token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The token of the admin user, when the admin kubeconfig uses one.",
+			},
+		},
+	}
+}
+
+func (r *openShiftClusterCredentialsEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// the provider isn't configured yet when the configuration is validated
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *clients.Client, got %T.", req.ProviderData))
+		return
+	}
+
+	r.client = client
+}
+
+func (r *openShiftClusterCredentialsEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Unconfigured provider", "The provider must be configured before the credentials of a cluster can be opened.")
+		return
+	}
+
+	var model openShiftClusterCredentialsModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := parse.ClusterID(model.ClusterId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("cluster_id"), "Invalid cluster ID", err.Error())
+		return
+	}
+
+	credentialsClient := r.client.OpenShiftCredentialsClient
+	ctx, tracker := clients.WithOperationTracker(ctx)
+
+	credentials, err := clients.Retry(ctx, r.client.RetryOptions, "listing credentials for Red Hat OpenShift Cluster", func() (redhatopenshift.OpenShiftClustersClientListCredentialsResponse, error) {
+		return credentialsClient.ListCredentials(ctx, id.ResourceGroup, id.ManagedClusterName)
+	})
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics(aroerrors.Diagnostics(fmt.Sprintf("listing credentials for Red Hat OpenShift Cluster %q (Resource Group %q)", id.ManagedClusterName, id.ResourceGroup), tracker.Wrap(err)), nil)...)
+		return
+	}
+
+	adminCredentials, err := clients.Retry(ctx, r.client.RetryOptions, "listing admin credentials for Red Hat OpenShift Cluster", func() (redhatopenshift.OpenShiftClustersClientListAdminCredentialsResponse, error) {
+		return credentialsClient.ListAdminCredentials(ctx, id.ResourceGroup, id.ManagedClusterName)
+	})
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics(aroerrors.Diagnostics(fmt.Sprintf("listing admin credentials for Red Hat OpenShift Cluster %q (Resource Group %q)", id.ManagedClusterName, id.ResourceGroup), tracker.Wrap(err)), nil)...)
+		return
+	}
+
+	model.KubeadminUsername = types.StringPointerValue(credentials.KubeadminUsername)
+	model.KubeadminPassword = types.StringPointerValue(credentials.KubeadminPassword)
+
+	if adminCredentials.Kubeconfig == nil {
+		resp.Diagnostics.AddError("Missing admin kubeconfig", fmt.Sprintf("The admin credentials of Red Hat OpenShift Cluster %q (Resource Group %q) have no kubeconfig.", id.ManagedClusterName, id.ResourceGroup))
+		return
+	}
+
+	// the kubeconfig is returned base64 encoded
+	kubeconfig, err := base64.StdEncoding.DecodeString(*adminCredentials.Kubeconfig)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid admin kubeconfig", fmt.Sprintf("decoding the admin kubeconfig of Red Hat OpenShift Cluster %q (Resource Group %q): %+v", id.ManagedClusterName, id.ResourceGroup, err))
+		return
+	}
+
+	parsed, err := aro.ParseKubeconfig(kubeconfig)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid admin kubeconfig", fmt.Sprintf("the admin kubeconfig of Red Hat OpenShift Cluster %q (Resource Group %q): %+v", id.ManagedClusterName, id.ResourceGroup, err))
+		return
+	}
+
+	model.Kubeconfig = types.StringValue(string(kubeconfig))
+	model.Host = types.StringValue(parsed.Host)
+	model.ClusterCACertificate = types.StringValue(parsed.ClusterCACertificate)
+	model.Insecure = types.BoolValue(parsed.Insecure)
+	model.ClientCertificate = types.StringValue(parsed.ClientCertificate)
+	model.ClientKey = types.StringValue(parsed.ClientKey)
+	model.Token = types.StringValue(parsed.Token)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &model)...)
+}
@@ -0,0 +1,121 @@
+package azureopenshift
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/fake"
+)
+
+func TestOpenShiftClusterCredentialsEphemeralResourceOpen(t *testing.T) {
+	testCases := []struct {
+		name         string
+		clusterId    string
+		existing     bool
+		failure      *fake.Failure
+		unconfigured bool
+		expectErr    string
+	}{
+		{
+			name:      "opened",
+			clusterId: testClusterId,
+			existing:  true,
+		},
+		{
+			name:      "invalid ID",
+			clusterId: "/subscriptions/" + fake.SubscriptionID + "/resourceGroups/" + fake.ResourceGroupName,
+			expectErr: "Invalid cluster ID",
+		},
+		{
+			name:      "gone",
+			clusterId: testClusterId,
+			expectErr: "listing credentials",
+		},
+		{
+			name:      "admin credentials refused",
+			clusterId: testClusterId,
+			existing:  true,
+			failure:   &fake.Failure{Operation: fake.OperationListAdminCredentials, StatusCode: 403, Code: "AuthorizationFailed"},
+			expectErr: "listing admin credentials",
+		},
+		{
+			name:         "unconfigured provider",
+			clusterId:    testClusterId,
+			existing:     true,
+			unconfigured: true,
+			expectErr:    "Unconfigured provider",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			clusters := fake.NewClustersClient()
+			if tc.existing {
+				clusters.SetCluster(fake.NewCluster(fake.ResourceGroupName, fake.ClusterName))
+			}
+			if tc.failure != nil {
+				clusters.InjectFailure(*tc.failure)
+			}
+
+			r := newOpenShiftClusterCredentialsEphemeralResource()
+			if !tc.unconfigured {
+				r.(ephemeral.EphemeralResourceWithConfigure).Configure(ctx, ephemeral.ConfigureRequest{ProviderData: testClient(clusters, fake.NewManagementLocksClient())}, &ephemeral.ConfigureResponse{})
+			}
+
+			schemaResp := &ephemeral.SchemaResponse{}
+			r.Schema(ctx, ephemeral.SchemaRequest{}, schemaResp)
+
+			// every attribute but the cluster ID is null in the configuration
+			objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+			values := make(map[string]tftypes.Value)
+			for name, attributeType := range objectType.AttributeTypes {
+				values[name] = tftypes.NewValue(attributeType, nil)
+			}
+			values["cluster_id"] = tftypes.NewValue(tftypes.String, tc.clusterId)
+
+			req := ephemeral.OpenRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}}
+			resp := &ephemeral.OpenResponse{Result: tfsdk.EphemeralResultData{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}}
+			r.Open(ctx, req, resp)
+
+			if tc.expectErr != "" {
+				var summaries []string
+				for _, d := range resp.Diagnostics.Errors() {
+					summaries = append(summaries, d.Summary()+": "+d.Detail())
+				}
+				if !resp.Diagnostics.HasError() || !strings.Contains(strings.Join(summaries, "\n"), tc.expectErr) {
+					t.Fatalf("expected an error containing %q, got %q", tc.expectErr, summaries)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("opening: %+v", resp.Diagnostics)
+			}
+
+			var model openShiftClusterCredentialsModel
+			if diags := resp.Result.Get(ctx, &model); diags.HasError() {
+				t.Fatalf("reading the result: %+v", diags)
+			}
+			if v := model.KubeadminUsername.ValueString(); v != fake.KubeadminUsername {
+				t.Fatalf("expected kubeadmin username %q, got %q", fake.KubeadminUsername, v)
+			}
+			if v := model.KubeadminPassword.ValueString(); v != fake.KubeadminPassword {
+				t.Fatalf("expected kubeadmin password %q, got %q", fake.KubeadminPassword, v)
+			}
+			if v := model.Host.ValueString(); !strings.HasPrefix(v, "https://api.") {
+				t.Fatalf("expected the API server URL to be parsed from the kubeconfig, got %q", v)
+			}
+			if v := model.Token.ValueString(); v == "" {
+				t.Fatalf("expected the token to be parsed from the kubeconfig")
+			}
+			if v := model.Kubeconfig.ValueString(); !strings.Contains(v, "kind: Config") {
+				t.Fatalf("expected the decoded kubeconfig, got %q", v)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package azureopenshift
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/clients"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/parse"
+)
+
+type openShiftClusterIdentityModel struct {
+	SubscriptionId    types.String `tfsdk:"subscription_id"`
+	ResourceGroupName types.String `tfsdk:"resource_group_name"`
+	Name              types.String `tfsdk:"name"`
+}
+
+// openShiftClusterIdentitySchema is the identity of a cluster from Terraform 1.12, which import blocks and list queries
+// can use instead of its ID.
+func openShiftClusterIdentitySchema() identityschema.Schema {
+	return identityschema.Schema{
+		Version: 0,
+		Attributes: map[string]identityschema.Attribute{
+			"subscription_id": identityschema.StringAttribute{
+				OptionalForImport: true,
+				Description:       "The ID of the subscription of the cluster, the subscription of the provider when not set.",
+			},
+			"resource_group_name": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The name of the resource group of the cluster.",
+			},
+			"name": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The name of the cluster.",
+			},
+		},
+	}
+}
+
+// importOpenShiftCluster imports a cluster by its ID, or by its identity.
+func importOpenShiftCluster(ctx context.Context, client *clients.Client, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	clusterId := req.ID
+	if clusterId == "" && req.Identity != nil {
+		var identity openShiftClusterIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		subscriptionId := identity.SubscriptionId.ValueString()
+		if subscriptionId == "" && client != nil {
+			subscriptionId = client.SubscriptionID
+		}
+
+		clusterId = parse.NewClusterID(subscriptionId, identity.ResourceGroupName.ValueString(), identity.Name.ValueString()).ID()
+	}
+
+	id, err := parse.ClusterID(clusterId)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid cluster ID", fmt.Sprintf("parsing Red Hat OpenShift Cluster ID %q: %+v", clusterId, err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id.ID())...)
+	resp.Diagnostics.Append(setOpenShiftClusterIdentity(ctx, resp.Identity, *id)...)
+}
+
+// setOpenShiftClusterIdentity sets the identity of the cluster with the ID.
+func setOpenShiftClusterIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, id parse.ClusterId) diag.Diagnostics {
+	// the identity is nil when Terraform doesn't support it
+	if identity == nil {
+		return nil
+	}
+
+	return identity.Set(ctx, openShiftClusterIdentityModel{
+		SubscriptionId:    types.StringValue(id.SubscriptionId),
+		ResourceGroupName: types.StringValue(id.ResourceGroup),
+		Name:              types.StringValue(id.ManagedClusterName),
+	})
+}
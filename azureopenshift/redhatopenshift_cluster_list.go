@@ -0,0 +1,210 @@
+package azureopenshift
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	redhatopenshift "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/redhatopenshift/armredhatopenshift"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/clients"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/parse"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/aroerrors"
+)
+
+// openShiftClusterListResource lists the clusters of the subscription for `list` blocks from Terraform 1.14, so that
+// `terraform query -generate-config-out` can write their configuration and import blocks.
+type openShiftClusterListResource struct {
+	client *clients.Client
+}
+
+var _ list.ListResourceWithConfigure = &openShiftClusterListResource{}
+
+func newOpenShiftClusterListResource() list.ListResource {
+	return &openShiftClusterListResource{}
+}
+
+type openShiftClusterListModel struct {
+	ResourceGroupName types.String `tfsdk:"resource_group_name"`
+	Tags              types.Map    `tfsdk:"tags"`
+}
+
+func (r *openShiftClusterListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_redhatopenshift_cluster"
+}
+
+func (r *openShiftClusterListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the Red Hat OpenShift clusters of the subscription.",
+		Attributes: map[string]schema.Attribute{
+			"resource_group_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the clusters of the resource group.",
+			},
+			"tags": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Only list the clusters with all of these tags.",
+			},
+		},
+	}
+}
+
+func (r *openShiftClusterListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// the provider isn't configured yet when the configuration is validated
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *clients.Client, got %T.", req.ProviderData))
+		return
+	}
+
+	r.client = client
+}
+
+func (r *openShiftClusterListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	if r.client == nil {
+		var diags diag.Diagnostics
+		diags.AddError("Unconfigured provider", "The provider must be configured before clusters can be listed.")
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var model openShiftClusterListModel
+	if diags := req.Config.Get(ctx, &model); diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	tags := make(map[string]string)
+	if diags := model.Tags.ElementsAs(ctx, &tags, false); diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var pushed int64
+		for cluster, err := range r.listClusters(ctx, model.ResourceGroupName.ValueString()) {
+			if err != nil {
+				result := req.NewListResult(ctx)
+				result.Diagnostics.Append(apiErrorDiagnostics(aroerrors.Diagnostics("listing Red Hat OpenShift Clusters", err), nil)...)
+				push(result)
+				return
+			}
+
+			if cluster.ID == nil || !openShiftClusterHasTags(cluster, tags) {
+				continue
+			}
+
+			if !push(r.listResult(ctx, req, cluster)) {
+				return
+			}
+
+			pushed++
+			if req.Limit > 0 && pushed >= req.Limit {
+				return
+			}
+		}
+	}
+}
+
+// listClusters iterates over the clusters of the resource group, or of the subscription when it's empty, stopping at
+// the first error.
+func (r *openShiftClusterListResource) listClusters(ctx context.Context, resourceGroupName string) iter.Seq2[*redhatopenshift.OpenShiftCluster, error] {
+	client := r.client.OpenShiftClustersClient
+
+	return func(yield func(*redhatopenshift.OpenShiftCluster, error) bool) {
+		if resourceGroupName != "" {
+			pager := client.NewListByResourceGroupPager(resourceGroupName)
+			for pager.More() {
+				page, err := pager.NextPage(ctx)
+				if err != nil {
+					yield(nil, fmt.Errorf("in Resource Group %q: %w", resourceGroupName, err))
+					return
+				}
+				for _, cluster := range page.Value {
+					if !yield(cluster, nil) {
+						return
+					}
+				}
+			}
+			return
+		}
+
+		pager := client.NewListPager()
+		for pager.More() {
+			page, err := pager.NextPage(ctx)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, cluster := range page.Value {
+				if !yield(cluster, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// listResult returns the identity of the cluster, along with its state when Terraform asks for it.  The state is built
+// from the listed cluster, without the credentials, the lock and the tags of the managed resource group which reading
+// the cluster would retrieve with a request per cluster, and which the generated configuration doesn't need.
+func (r *openShiftClusterListResource) listResult(ctx context.Context, req list.ListRequest, cluster *redhatopenshift.OpenShiftCluster) list.ListResult {
+	result := req.NewListResult(ctx)
+
+	id, err := parse.ClusterID(*cluster.ID)
+	if err != nil {
+		result.Diagnostics.AddError("Invalid cluster ID", err.Error())
+		return result
+	}
+	result.DisplayName = fmt.Sprintf("%s (Resource Group %s)", id.ManagedClusterName, id.ResourceGroup)
+
+	result.Diagnostics.Append(setOpenShiftClusterIdentity(ctx, result.Identity, *id)...)
+	if !req.IncludeResource || result.Diagnostics.HasError() {
+		return result
+	}
+
+	// the state starts with every attribute null, rather than as a null object, so that it can be read into the model
+	objectType := result.Resource.Raw.Type().(tftypes.Object)
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+	result.Resource.Raw = tftypes.NewValue(objectType, attributes)
+
+	var model openShiftClusterModel
+	result.Diagnostics.Append(result.Resource.Get(ctx, &model)...)
+	if result.Diagnostics.HasError() {
+		return result
+	}
+
+	result.Diagnostics.Append(model.flatten(ctx, *id, *cluster, r.client.TagsConfig)...)
+	if result.Diagnostics.HasError() {
+		return result
+	}
+
+	result.Diagnostics.Append(result.Resource.Set(ctx, &model)...)
+
+	return result
+}
+
+// openShiftClusterHasTags returns whether the cluster has all of the tags.
+func openShiftClusterHasTags(cluster *redhatopenshift.OpenShiftCluster, tags map[string]string) bool {
+	for k, v := range tags {
+		if value, ok := cluster.Tags[k]; !ok || value == nil || *value != v {
+			return false
+		}
+	}
+
+	return true
+}
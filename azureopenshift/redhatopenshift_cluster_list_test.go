@@ -0,0 +1,179 @@
+package azureopenshift
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/fake"
+)
+
+var testClusterListConfigType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+	"resource_group_name": tftypes.String,
+	"tags":                tftypes.Map{ElementType: tftypes.String},
+}}
+
+// testClusterListConfig returns the configuration of a list block, leaving out the empty filters.
+func testClusterListConfig(t *testing.T, resourceGroupName string, tags map[string]string) *tfprotov5.DynamicValue {
+	resourceGroupValue := tftypes.NewValue(tftypes.String, nil)
+	if resourceGroupName != "" {
+		resourceGroupValue = tftypes.NewValue(tftypes.String, resourceGroupName)
+	}
+
+	tagsValue := tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil)
+	if tags != nil {
+		values := make(map[string]tftypes.Value)
+		for k, v := range tags {
+			values[k] = tftypes.NewValue(tftypes.String, v)
+		}
+		tagsValue = tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, values)
+	}
+
+	config, err := tfprotov5.NewDynamicValue(testClusterListConfigType, tftypes.NewValue(testClusterListConfigType, map[string]tftypes.Value{
+		"resource_group_name": resourceGroupValue,
+		"tags":                tagsValue,
+	}))
+	if err != nil {
+		t.Fatalf("building the configuration: %+v", err)
+	}
+
+	return &config
+}
+
+func TestOpenShiftClusterListResource(t *testing.T) {
+	testCases := []struct {
+		name              string
+		resourceGroupName string
+		tags              map[string]string
+		limit             int64
+		includeResource   bool
+		failure           *fake.Failure
+		unconfigured      bool
+		expectNames       []string
+		expectErr         string
+	}{
+		{
+			name:        "in the subscription",
+			expectNames: []string{"other-cluster", "prod-cluster", fake.ClusterName},
+		},
+		{
+			name:              "in a resource group",
+			resourceGroupName: fake.ResourceGroupName,
+			expectNames:       []string{"prod-cluster", fake.ClusterName},
+		},
+		{
+			name:        "with tags",
+			tags:        map[string]string{"env": "dev"},
+			expectNames: []string{"other-cluster", fake.ClusterName},
+		},
+		{
+			name:        "limited",
+			limit:       1,
+			expectNames: []string{"other-cluster"},
+		},
+		{
+			name:              "with their state",
+			resourceGroupName: fake.ResourceGroupName,
+			tags:              map[string]string{"env": "dev"},
+			includeResource:   true,
+			expectNames:       []string{fake.ClusterName},
+		},
+		{
+			name:      "refused",
+			failure:   &fake.Failure{Operation: fake.OperationList, StatusCode: 403, Code: "AuthorizationFailed"},
+			expectErr: "listing Red Hat OpenShift Clusters",
+		},
+		{
+			name:         "unconfigured provider",
+			unconfigured: true,
+			expectErr:    "Unconfigured provider",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			clusters := fake.NewClustersClient()
+			clusters.SetCluster(fake.NewCluster(fake.ResourceGroupName, fake.ClusterName))
+			prod := fake.NewCluster(fake.ResourceGroupName, fake.ClusterName)
+			prod.ID = to.Ptr(fake.ClusterID(fake.ResourceGroupName, "prod-cluster"))
+			prod.Tags = map[string]*string{"env": to.Ptr("prod")}
+			clusters.SetCluster(prod)
+			other := fake.NewCluster(fake.ResourceGroupName, fake.ClusterName)
+			other.ID = to.Ptr(fake.ClusterID("other-rg", "other-cluster"))
+			clusters.SetCluster(other)
+			if tc.failure != nil {
+				clusters.InjectFailure(*tc.failure)
+			}
+
+			sdkProvider := Provider()
+			sdkProvider.SetMeta(testClient(clusters, fake.NewManagementLocksClient()))
+			server := providerserver.NewProtocol5(NewFrameworkProvider(sdkProvider))()
+			if !tc.unconfigured {
+				if _, err := server.ConfigureProvider(ctx, &tfprotov5.ConfigureProviderRequest{}); err != nil {
+					t.Fatalf("configuring the provider: %+v", err)
+				}
+			}
+
+			stream, err := server.(tfprotov5.ListResourceServer).ListResource(ctx, &tfprotov5.ListResourceRequest{
+				TypeName:        "azureopenshift_redhatopenshift_cluster",
+				Config:          testClusterListConfig(t, tc.resourceGroupName, tc.tags),
+				IncludeResource: tc.includeResource,
+				Limit:           tc.limit,
+			})
+			if err != nil {
+				t.Fatalf("listing clusters: %+v", err)
+			}
+
+			var names []string
+			for result := range stream.Results {
+				for _, d := range result.Diagnostics {
+					if d.Severity != tfprotov5.DiagnosticSeverityError {
+						continue
+					}
+					if tc.expectErr != "" && strings.Contains(d.Summary+d.Detail, tc.expectErr) {
+						return
+					}
+					t.Fatalf("unexpected error: %s: %s", d.Summary, d.Detail)
+				}
+
+				identity, err := result.Identity.IdentityData.Unmarshal(testClusterIdentityType)
+				if err != nil {
+					t.Fatalf("decoding the identity: %+v", err)
+				}
+				var attributes map[string]tftypes.Value
+				var name string
+				if err := identity.As(&attributes); err != nil {
+					t.Fatalf("decoding the identity: %+v", err)
+				}
+				if err := attributes["name"].As(&name); err != nil {
+					t.Fatalf("decoding the identity: %+v", err)
+				}
+				names = append(names, name)
+
+				if tc.includeResource != (result.Resource != nil) {
+					t.Fatalf("expected the state to be included %t, got %+v", tc.includeResource, result.Resource)
+				}
+			}
+
+			if tc.expectErr != "" {
+				t.Fatalf("expected an error containing %q", tc.expectErr)
+			}
+			if strings.Join(names, ",") != strings.Join(tc.expectNames, ",") {
+				t.Fatalf("expected clusters %v, got %v", tc.expectNames, names)
+			}
+			// the state is built from the listed clusters alone
+			for _, call := range clusters.Calls() {
+				if operation := strings.Fields(call)[0]; operation != fake.OperationList && operation != fake.OperationListByResourceGroup {
+					t.Fatalf("expected the clusters to only be listed, got %q", call)
+				}
+			}
+		})
+	}
+}
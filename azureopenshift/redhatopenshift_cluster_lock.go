@@ -0,0 +1,92 @@
+package azureopenshift
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armlocks"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/clients"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/utils"
+)
+
+// clusterLockName is the name of the management lock created on the cluster for `lock_level`.
+const clusterLockName = "terraform-azureopenshift"
+
+// createClusterLock creates the management lock on the cluster, or updates its level when it already exists.
+func createClusterLock(ctx context.Context, client *clients.Client, clusterId string, level string) error {
+	lockLevel := armlocks.LockLevel(level)
+	notes := "Managed by the azureopenshift Terraform provider through the cluster's `lock_level`."
+	parameters := armlocks.ManagementLockObject{
+		Properties: &armlocks.ManagementLockProperties{
+			Level: &lockLevel,
+			Notes: &notes,
+		},
+	}
+
+	_, err := clients.Retry(ctx, client.RetryOptions, "creating management lock", func() (armlocks.ManagementLocksClientCreateOrUpdateByScopeResponse, error) {
+		return client.ManagementLocksClient.CreateOrUpdateByScope(ctx, clusterId, clusterLockName, parameters, nil)
+	})
+	if err != nil {
+		return fmt.Errorf("creating %s management lock %q on %q: %+v", level, clusterLockName, clusterId, err)
+	}
+
+	return nil
+}
+
+// deleteClusterLock removes the management lock from the cluster, if it exists.
+func deleteClusterLock(ctx context.Context, client *clients.Client, clusterId string) error {
+	_, err := clients.Retry(ctx, client.RetryOptions, "deleting management lock", func() (armlocks.ManagementLocksClientDeleteByScopeResponse, error) {
+		return client.ManagementLocksClient.DeleteByScope(ctx, clusterId, clusterLockName, nil)
+	})
+	if err != nil && !utils.ResponseWasNotFound(err) {
+		return fmt.Errorf("deleting management lock %q from %q: %+v", clusterLockName, clusterId, err)
+	}
+
+	return nil
+}
+
+// readClusterLock returns the level of the management lock on the cluster, or an empty string when there is none.
+func readClusterLock(ctx context.Context, client *clients.Client, clusterId string) (string, error) {
+	resp, err := clients.Retry(ctx, client.RetryOptions, "retrieving management lock", func() (armlocks.ManagementLocksClientGetByScopeResponse, error) {
+		return client.ManagementLocksClient.GetByScope(ctx, clusterId, clusterLockName, nil)
+	})
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("retrieving management lock %q on %q: %w", clusterLockName, clusterId, err)
+	}
+
+	if resp.Properties == nil || resp.Properties.Level == nil {
+		return "", nil
+	}
+
+	return string(*resp.Properties.Level), nil
+}
+
+// withoutClusterLock removes the management lock of the given level from the cluster while fn runs, and restores it
+// when fn fails, so that the lock only guards against changes made outside of Terraform.  Its errors are annotated with
+// the identifiers recorded by tracker.
+func withoutClusterLock(ctx context.Context, client *clients.Client, tracker *clients.OperationTracker, clusterId string, level string, fn func() diag.Diagnostics) diag.Diagnostics {
+	if level == "" {
+		return fn()
+	}
+
+	log.Printf("[DEBUG] removing %s management lock %q from %q", level, clusterLockName, clusterId)
+	if err := deleteClusterLock(ctx, client, clusterId); err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("removing the management lock of the cluster", tracker.Wrap(err).Error())}
+	}
+
+	diags := fn()
+	if diags.HasError() {
+		log.Printf("[DEBUG] restoring %s management lock %q on %q", level, clusterLockName, clusterId)
+		if err := createClusterLock(ctx, client, clusterId, level); err != nil {
+			diags.AddError("restoring the management lock of the cluster, which is no longer locked", tracker.Wrap(err).Error())
+		}
+	}
+
+	return diags
+}
@@ -0,0 +1,215 @@
+package azureopenshift
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/clients"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/parse"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/azure"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/utils"
+)
+
+// expandManagedResourceGroupTags returns the tags to apply to the cluster's managed resource group: the cluster's
+// tags, including the provider's default tags, when they are propagated, overridden by `managed_resource_group_tags`.
+func expandManagedResourceGroupTags(clusterTags, managedTags map[string]interface{}, propagate bool) map[string]interface{} {
+	output := make(map[string]interface{}, len(clusterTags)+len(managedTags))
+
+	if propagate {
+		for k, v := range clusterTags {
+			output[k] = v
+		}
+	}
+
+	for k, v := range managedTags {
+		output[k] = v
+	}
+
+	return output
+}
+
+// planManagedResourceGroupTags plans `managed_resource_group_tags_applied` as the tags wanted on the managed resource
+// group whenever they differ from those found on it, e.g. after they were changed outside of Terraform.
+func planManagedResourceGroupTags(ctx context.Context, plan, state *openShiftClusterModel, tagsConfig azure.TagsConfig) diag.Diagnostics {
+	if plan.Tags.IsUnknown() || plan.ManagedResourceGroupTags.IsUnknown() || plan.PropagateTagsToManagedResourceGroup.IsUnknown() {
+		plan.ManagedResourceGroupTagsApplied = types.MapUnknown(types.StringType)
+		return nil
+	}
+
+	var diags diag.Diagnostics
+	wanted := expandManagedResourceGroupTags(
+		tagsConfig.MergeDefaults(tagsMap(ctx, plan.Tags, &diags)),
+		tagsMap(ctx, plan.ManagedResourceGroupTags, &diags),
+		plan.PropagateTagsToManagedResourceGroup.ValueBool(),
+	)
+
+	if state != nil && azure.TagsEqual(wanted, tagsMap(ctx, state.ManagedResourceGroupTagsApplied, &diags)) {
+		plan.ManagedResourceGroupTagsApplied = state.ManagedResourceGroupTagsApplied
+	} else {
+		plan.ManagedResourceGroupTagsApplied = tagsValue(ctx, wanted, &diags)
+	}
+
+	return diags
+}
+
+// updateManagedResourceGroupTags applies the tags to the managed resource group and every resource in it, and removes
+// the tags which were applied before but are no longer wanted.  The tags are written through the tags API since the
+// deny assignment ARO places on the managed resource group blocks updates of the resources themselves; scopes where the
+// deny assignment blocks the tags API too are reported as warnings, as the cluster itself is unaffected.
+func updateManagedResourceGroupTags(ctx context.Context, client *clients.Client, resourceGroupId string, old, new map[string]interface{}) diag.Diagnostics {
+	id, err := parse.ResourceGroupID(resourceGroupId)
+	if err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("parsing the ID of the managed resource group", err.Error())}
+	}
+
+	resources, err := listManagedResources(ctx, client, *id)
+	if err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic(fmt.Sprintf("listing resources in %s", id), err.Error())}
+	}
+
+	scopes := []string{id.ID()}
+	for _, resource := range resources {
+		scopes = append(scopes, *resource.ID)
+	}
+
+	removed := make(map[string]interface{})
+	for k, v := range old {
+		if _, ok := new[k]; !ok {
+			removed[k] = v
+		}
+	}
+
+	var diags diag.Diagnostics
+	for _, scope := range scopes {
+		if err := patchTags(ctx, client, scope, armresources.TagsPatchOperationDelete, removed); err != nil {
+			diags = append(diags, managedTagsDiagnostic(fmt.Sprintf("removing tags from %q", scope), err))
+			continue
+		}
+
+		if err := patchTags(ctx, client, scope, armresources.TagsPatchOperationMerge, new); err != nil {
+			diags = append(diags, managedTagsDiagnostic(fmt.Sprintf("applying tags to %q", scope), err))
+		}
+	}
+
+	return diags
+}
+
+// untaggableResourceTypes are the types of the resources ARO may place in the managed resource group which don't support
+// tags, see https://learn.microsoft.com/azure/azure-resource-manager/management/tag-support.
+var untaggableResourceTypes = map[string]bool{
+	"microsoft.compute/restorepointcollections/restorepoints":   true,
+	"microsoft.compute/virtualmachinescalesets/virtualmachines": true,
+	"microsoft.network/loadbalancers/inboundnatrules":           true,
+	"microsoft.network/networksecuritygroups/securityrules":     true,
+	"microsoft.network/virtualnetworks/subnets":                 true,
+	"microsoft.storage/storageaccounts/blobservices":            true,
+}
+
+// listManagedResources returns the resources of the managed resource group which support tags.
+func listManagedResources(ctx context.Context, client *clients.Client, id parse.ResourceGroupId) ([]*armresources.GenericResourceExpanded, error) {
+	var resources []*armresources.GenericResourceExpanded
+	pager := client.ResourcesClient.NewListByResourceGroupPager(id.Name, nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, resource := range page.Value {
+			if resource == nil || resource.ID == nil {
+				continue
+			}
+			if resource.Type != nil && untaggableResourceTypes[strings.ToLower(*resource.Type)] {
+				log.Printf("[DEBUG] skipping %q as %q resources don't support tags", *resource.ID, *resource.Type)
+				continue
+			}
+			resources = append(resources, resource)
+		}
+	}
+
+	return resources, nil
+}
+
+func patchTags(ctx context.Context, client *clients.Client, scope string, operation armresources.TagsPatchOperation, tags map[string]interface{}) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	parameters := armresources.TagsPatchResource{
+		Operation: &operation,
+		Properties: &armresources.Tags{
+			Tags: azure.TagsExpand(tags, azure.TagsConfig{}),
+		},
+	}
+
+	_, err := clients.Retry(ctx, client.RetryOptions, "updating tags", func() (armresources.TagsClientUpdateAtScopeResponse, error) {
+		return client.TagsClient.UpdateAtScope(ctx, scope, parameters, nil)
+	})
+
+	return err
+}
+
+func managedTagsDiagnostic(summary string, err error) diag.Diagnostic {
+	if utils.ResponseWasDeniedByDenyAssignment(err) {
+		return diag.NewWarningDiagnostic(fmt.Sprintf("%s: denied by the ARO deny assignment", summary), err.Error())
+	}
+
+	return diag.NewErrorDiagnostic(summary, err.Error())
+}
+
+// flattenManagedResourceGroupTags returns the values found on the managed resource group and the resources in it for
+// the tags which were applied to them, omitting those which were removed from, or changed on, any of them.
+func flattenManagedResourceGroupTags(ctx context.Context, client *clients.Client, resourceGroupId string, applied map[string]interface{}) (map[string]interface{}, error) {
+	if len(applied) == 0 {
+		return applied, nil
+	}
+
+	id, err := parse.ResourceGroupID(resourceGroupId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Retry(ctx, client.RetryOptions, "retrieving tags", func() (armresources.TagsClientGetAtScopeResponse, error) {
+		return client.TagsClient.GetAtScope(ctx, id.ID(), nil)
+	})
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			log.Printf("[DEBUG] %s was not found - assuming its tags are unchanged", id)
+			return applied, nil
+		}
+		return nil, fmt.Errorf("retrieving tags of %s: %+v", id, err)
+	}
+
+	var found map[string]interface{}
+	if resp.Properties != nil {
+		found = azure.Flatten(resp.Properties.Tags)
+	}
+
+	output := make(map[string]interface{}, len(applied))
+	for k := range applied {
+		if v, ok := found[k]; ok {
+			output[k] = v
+		}
+	}
+
+	resources, err := listManagedResources(ctx, client, *id)
+	if err != nil {
+		return nil, fmt.Errorf("listing resources in %s: %+v", id, err)
+	}
+	for _, resource := range resources {
+		for k, v := range output {
+			if value, ok := resource.Tags[k]; !ok || value == nil || *value != v {
+				log.Printf("[DEBUG] the %q tag of %q differs from the managed resource group", k, *resource.ID)
+				delete(output, k)
+			}
+		}
+	}
+
+	return output, nil
+}
@@ -0,0 +1,192 @@
+package azureopenshift
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// openShiftClusterModel is the state of `azureopenshift_redhatopenshift_cluster`.  The blocks are kept as lists, as
+// the number of blocks configured may only be known on apply.
+type openShiftClusterModel struct {
+	Id                                  types.String   `tfsdk:"id"`
+	Name                                types.String   `tfsdk:"name"`
+	Location                            types.String   `tfsdk:"location"`
+	ResourceGroupName                   types.String   `tfsdk:"resource_group_name"`
+	ClusterResourceGroup                types.String   `tfsdk:"cluster_resource_group"`
+	DomainPrefix                        types.String   `tfsdk:"domain_prefix"`
+	NameSeed                            types.String   `tfsdk:"name_seed"`
+	ClusterProfile                      types.List     `tfsdk:"cluster_profile"`
+	ServicePrincipal                    types.List     `tfsdk:"service_principal"`
+	NetworkProfile                      types.List     `tfsdk:"network_profile"`
+	MasterProfile                       types.List     `tfsdk:"master_profile"`
+	KubeadminUsername                   types.String   `tfsdk:"kubeadmin_username"`
+	KubeadminPassword                   types.String   `tfsdk:"kubeadmin_password"`
+	WorkerProfile                       types.List     `tfsdk:"worker_profile"`
+	ApiServerProfile                    types.List     `tfsdk:"api_server_profile"`
+	IngressProfile                      types.List     `tfsdk:"ingress_profile"`
+	Version                             types.String   `tfsdk:"version"`
+	ConsoleUrl                          types.String   `tfsdk:"console_url"`
+	LastOperation                       types.List     `tfsdk:"last_operation"`
+	Tags                                types.Map      `tfsdk:"tags"`
+	LockLevel                           types.String   `tfsdk:"lock_level"`
+	DeletionProtection                  types.Bool     `tfsdk:"deletion_protection"`
+	TagsAll                             types.Map      `tfsdk:"tags_all"`
+	ManagedResourceGroupTags            types.Map      `tfsdk:"managed_resource_group_tags"`
+	PropagateTagsToManagedResourceGroup types.Bool     `tfsdk:"propagate_tags_to_managed_resource_group"`
+	ManagedResourceGroupTagsApplied     types.Map      `tfsdk:"managed_resource_group_tags_applied"`
+	Timeouts                            timeouts.Value `tfsdk:"timeouts"`
+}
+
+type openShiftClusterProfileModel struct {
+	PullSecret                 types.String `tfsdk:"pull_secret"`
+	PullSecretWriteOnly        types.String `tfsdk:"pull_secret_wo"`
+	PullSecretWriteOnlyVersion types.Int64  `tfsdk:"pull_secret_wo_version"`
+	Domain                     types.String `tfsdk:"domain"`
+	Version                    types.String `tfsdk:"version"`
+	ResourceGroupId            types.String `tfsdk:"resource_group_id"`
+	FipsValidatedModules       types.String `tfsdk:"fips_validated_modules"`
+}
+
+type openShiftServicePrincipalModel struct {
+	ClientId                     types.String `tfsdk:"client_id"`
+	ClientSecret                 types.String `tfsdk:"client_secret"`
+	ClientSecretWriteOnly        types.String `tfsdk:"client_secret_wo"`
+	ClientSecretWriteOnlyVersion types.Int64  `tfsdk:"client_secret_wo_version"`
+}
+
+type openShiftNetworkProfileModel struct {
+	PodCidr      types.String `tfsdk:"pod_cidr"`
+	ServiceCidr  types.String `tfsdk:"service_cidr"`
+	OutboundType types.String `tfsdk:"outbound_type"`
+}
+
+type openShiftMasterProfileModel struct {
+	SubnetId          types.String `tfsdk:"subnet_id"`
+	VmSize            types.String `tfsdk:"vm_size"`
+	EncryptionAtHost  types.String `tfsdk:"encryption_at_host"`
+	DiskEncryptionSet types.String `tfsdk:"disk_encryption_set"`
+}
+
+type openShiftWorkerProfileModel struct {
+	VmSize            types.String `tfsdk:"vm_size"`
+	DiskSizeGb        types.Int64  `tfsdk:"disk_size_gb"`
+	NodeCount         types.Int64  `tfsdk:"node_count"`
+	SubnetId          types.String `tfsdk:"subnet_id"`
+	EncryptionAtHost  types.String `tfsdk:"encryption_at_host"`
+	DiskEncryptionSet types.String `tfsdk:"disk_encryption_set"`
+}
+
+type openShiftAPIServerProfileModel struct {
+	Visibility types.String `tfsdk:"visibility"`
+	Url        types.String `tfsdk:"url"`
+	Ip         types.String `tfsdk:"ip"`
+}
+
+type openShiftIngressProfileModel struct {
+	Visibility types.String `tfsdk:"visibility"`
+	Ip         types.String `tfsdk:"ip"`
+}
+
+type openShiftOperationModel struct {
+	RequestId            types.String `tfsdk:"request_id"`
+	CorrelationRequestId types.String `tfsdk:"correlation_request_id"`
+	AsyncOperationUrl    types.String `tfsdk:"async_operation_url"`
+}
+
+// firstBlock returns the first element of the list block, or nil when it has none or isn't known yet.
+func firstBlock[T any](ctx context.Context, block types.List, diags *diag.Diagnostics) *T {
+	if block.IsNull() || block.IsUnknown() || len(block.Elements()) == 0 {
+		return nil
+	}
+
+	var elements []T
+	diags.Append(block.ElementsAs(ctx, &elements, false)...)
+	if diags.HasError() || len(elements) == 0 {
+		return nil
+	}
+
+	return &elements[0]
+}
+
+// blockValue returns the list block with the element type holding the element, or no element when it's nil.
+func blockValue[T any](ctx context.Context, elementType attr.Type, element *T, diags *diag.Diagnostics) types.List {
+	if element == nil {
+		return types.ListValueMust(elementType, []attr.Value{})
+	}
+
+	v, d := types.ListValueFrom(ctx, elementType, []T{*element})
+	diags.Append(d...)
+
+	return v
+}
+
+// tagsMap returns the tags as the map shared with helpers/azure, which is empty when they're null or unknown.
+func tagsMap(ctx context.Context, tags types.Map, diags *diag.Diagnostics) map[string]interface{} {
+	output := make(map[string]interface{}, len(tags.Elements()))
+	if tags.IsNull() || tags.IsUnknown() {
+		return output
+	}
+
+	var values map[string]string
+	diags.Append(tags.ElementsAs(ctx, &values, false)...)
+	for k, v := range values {
+		output[k] = v
+	}
+
+	return output
+}
+
+// tagsValue returns the tags shared with helpers/azure as a map of strings.
+func tagsValue(ctx context.Context, tags map[string]interface{}, diags *diag.Diagnostics) types.Map {
+	values := make(map[string]string, len(tags))
+	for k, v := range tags {
+		values[k], _ = v.(string)
+	}
+
+	v, d := types.MapValueFrom(ctx, types.StringType, values)
+	diags.Append(d...)
+
+	return v
+}
+
+// stringValue returns the value of the API's string or enum, or an empty string when it's nil as SDKv2 stored.
+func stringValue[T ~string](v *T) types.String {
+	if v == nil {
+		return types.StringValue("")
+	}
+
+	return types.StringValue(string(*v))
+}
+
+// int64Value returns the value of the API's number, or zero when it's nil as SDKv2 stored.
+func int64Value(v *int32) types.Int64 {
+	if v == nil {
+		return types.Int64Value(0)
+	}
+
+	return types.Int64Value(int64(*v))
+}
+
+// optionalString returns null for an empty string, which isn't sent to the API.
+func optionalString(v types.String) types.String {
+	if v.ValueString() == "" {
+		return types.StringNull()
+	}
+
+	return v
+}
+
+// locationValue returns the location of the cluster, keeping the prior one when it's only written differently, as
+// Terraform expects the configured location back.
+func locationValue(prior types.String, v *string) types.String {
+	if v != nil && !prior.IsNull() && !prior.IsUnknown() && location.Normalize(prior.ValueString()) == location.Normalize(*v) {
+		return prior
+	}
+
+	return stringValue(v)
+}
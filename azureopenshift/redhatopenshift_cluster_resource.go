@@ -4,20 +4,43 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	redhatopenshift "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/redhatopenshift/armredhatopenshift"
-	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armlocks"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourcegroups"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
 	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/clients"
 	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/parse"
 	openShiftValidate "github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/validate"
 	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/aro"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/aroerrors"
 	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/azure"
-	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/suppress"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/locks"
 	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/tf"
 	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/utils"
 	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/validate"
@@ -32,752 +55,1449 @@ const (
 	StandardD4sV3 string = "Standard_D4s_v3"
 )
 
-func resourceOpenShiftCluster() *schema.Resource {
-	return &schema.Resource{
+// openShiftClusterResource is `azureopenshift_redhatopenshift_cluster`.  It was served by SDKv2, whose schema it keeps
+// so that existing configurations keep working.  The blocks which SDKv2 computed when they weren't configured are only
+// kept in state when they're configured, as Terraform doesn't let the framework plan them otherwise, and the zero
+// values SDKv2 stored for the attributes which aren't configured are nulled when its states are upgraded.
+type openShiftClusterResource struct {
+	client *clients.Client
+}
+
+var (
+	_ resource.ResourceWithConfigure      = &openShiftClusterResource{}
+	_ resource.ResourceWithIdentity       = &openShiftClusterResource{}
+	_ resource.ResourceWithImportState    = &openShiftClusterResource{}
+	_ resource.ResourceWithModifyPlan     = &openShiftClusterResource{}
+	_ resource.ResourceWithValidateConfig = &openShiftClusterResource{}
+)
+
+func newOpenShiftClusterResource() resource.Resource {
+	return &openShiftClusterResource{}
+}
+
+func (r *openShiftClusterResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_redhatopenshift_cluster"
+}
+
+func (r *openShiftClusterResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// version 0 is the state written by SDKv2
+		Version: 1,
+
 		DeprecationMessage: "The 'azureopenshift_redhatopenshift_cluster' resource is deprecated.  Please use " +
 			"'azurerm_redhat_openshift_cluster' instead as this resource is no longer maintained.  See " +
 			"https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs/resources/redhat_openshift_cluster " +
 			"for more details.",
 
-		Create: resourceOpenShiftClusterCreate,
-		Read:   resourceOpenShiftClusterRead,
-		Update: resourceOpenShiftClusterUpdate,
-		Delete: resourceOpenShiftClusterDelete,
-
-		Timeouts: &schema.ResourceTimeout{
-			Create: schema.DefaultTimeout(90 * time.Minute),
-			Read:   schema.DefaultTimeout(5 * time.Minute),
-			Update: schema.DefaultTimeout(90 * time.Minute),
-			Delete: schema.DefaultTimeout(90 * time.Minute),
-		},
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: validation.StringIsNotEmpty,
+			"name": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
 
-			"location": commonschema.Location(),
+			"location": locationAttribute(),
 
-			"resource_group_name": commonschema.ResourceGroupName(),
+			"resource_group_name": resourceGroupNameAttribute(),
 
-			"cluster_resource_group": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ValidateFunc: validation.StringIsNotEmpty,
+			"cluster_resource_group": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
 
-			"cluster_profile": {
-				Type:     schema.TypeList,
+			"domain_prefix": schema.StringAttribute{
 				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z]([a-z0-9-]{0,22}[a-z0-9])?$`), "must start with a lower case letter, end with a lower case letter or digit and only contain lower case letters, digits and dashes, up to 24 characters"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"name_seed": schema.StringAttribute{
 				Computed: true,
-				MaxItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"pull_secret": {
-							Type:         schema.TypeString,
-							Optional:     true,
-							ValidateFunc: validation.StringIsNotEmpty,
-						},
-						"domain": {
-							Type:         schema.TypeString,
-							Optional:     true,
-							ForceNew:     true,
-							ValidateFunc: validation.StringIsNotEmpty,
-							Computed:     true,
-						},
-						"version": {
-							Type:         schema.TypeString,
-							Optional:     true,
-							ValidateFunc: validation.StringIsNotEmpty,
-							Computed:     true,
-						},
-						"resource_group_id": {
-							Type:     schema.TypeString,
-							Computed: true,
-							Optional: true,
-						},
-						"fips_validated_modules": {
-							Type:         schema.TypeString,
-							Optional:     true,
-							Default:      redhatopenshift.FipsValidatedModulesDisabled,
-							ValidateFunc: validation.StringIsNotEmpty,
-						},
-					},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 
-			"service_principal": {
-				Type:     schema.TypeList,
-				Required: true,
-				MaxItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"client_id": {
-							Type:         schema.TypeString,
-							Required:     true,
-							ValidateFunc: openShiftValidate.ClientID,
-						},
-						"client_secret": {
-							Type:         schema.TypeString,
-							Required:     true,
-							Sensitive:    true,
-							ValidateFunc: validation.StringIsNotEmpty,
-						},
-					},
+			"kubeadmin_username": schema.StringAttribute{
+				Optional:  true,
+				Computed:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"kubeadmin_password": schema.StringAttribute{
+				Optional:  true,
+				Computed:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"version": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"console_url": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 
-			"network_profile": {
-				Type:     schema.TypeList,
+			// SDKv2 stored the operation as a list of objects, which protocol 5 can't describe as nested attributes
+			"last_operation": schema.ListAttribute{
+				Computed: true,
+				ElementType: types.ObjectType{AttrTypes: map[string]attr.Type{
+					"request_id":             types.StringType,
+					"correlation_request_id": types.StringType,
+					"async_operation_url":    types.StringType,
+				}},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"tags": tagsAttribute(),
+
+			"lock_level": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(armlocks.LockLevelCanNotDelete),
+						string(armlocks.LockLevelReadOnly),
+					),
+				},
+			},
+
+			"deletion_protection": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+
+			"tags_all": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+
+			"managed_resource_group_tags": tagsAttribute(),
+
+			"propagate_tags_to_managed_resource_group": schema.BoolAttribute{
 				Optional: true,
 				Computed: true,
-				ForceNew: true,
-				MaxItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"pod_cidr": {
-							Type:         schema.TypeString,
-							Optional:     true,
-							ForceNew:     true,
-							Default:      "10.128.0.0/14",
-							ValidateFunc: validate.CIDR,
+				Default:  booldefault.StaticBool(false),
+			},
+
+			"managed_resource_group_tags_applied": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"cluster_profile": schema.ListNestedBlock{
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"pull_secret": schema.StringAttribute{
+							Optional:  true,
+							Sensitive: true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
 						},
-						"service_cidr": {
-							Type:         schema.TypeString,
-							Optional:     true,
-							ForceNew:     true,
-							Default:      "172.30.0.0/16",
-							ValidateFunc: validate.CIDR,
+						"pull_secret_wo": schema.StringAttribute{
+							Optional:  true,
+							Sensitive: true,
+							WriteOnly: true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+								stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("pull_secret")),
+							},
 						},
-						"outbound_type": {
-							Type:         schema.TypeString,
-							Optional:     true,
-							ForceNew:     true,
-							Default:      redhatopenshift.OutboundTypeLoadbalancer,
-							ValidateFunc: validate.ValidateOutBoundType,
+						"pull_secret_wo_version": schema.Int64Attribute{
+							Optional: true,
+							Validators: []validator.Int64{
+								int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("pull_secret_wo")),
+							},
 						},
-					},
-				},
-			},
-
-			"master_profile": {
-				Type:     schema.TypeList,
-				Required: true,
-				MaxItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"subnet_id": {
-							Type:         schema.TypeString,
-							Required:     true,
-							ValidateFunc: azure.ValidateResourceID,
+						"domain": schema.StringAttribute{
+							Optional: true,
+							Computed: true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+								stringplanmodifier.RequiresReplace(),
+							},
 						},
-						"vm_size": {
-							Type:             schema.TypeString,
-							Optional:         true,
-							ForceNew:         true,
-							Default:          StandardD8sV3,
-							DiffSuppressFunc: suppress.CaseDifference,
-							ValidateFunc:     validation.StringIsNotEmpty,
+						"version": schema.StringAttribute{
+							Optional: true,
+							Computed: true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
 						},
-						"encryption_at_host": {
-							Type:         schema.TypeString,
-							Optional:     true,
-							Default:      redhatopenshift.EncryptionAtHostDisabled,
-							ValidateFunc: validation.StringIsNotEmpty,
+						"resource_group_id": schema.StringAttribute{
+							Optional: true,
+							Computed: true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
 						},
-						"disk_encryption_set": {
-							Type:     schema.TypeString,
+						"fips_validated_modules": schema.StringAttribute{
 							Optional: true,
+							Computed: true,
+							Default:  stringdefault.StaticString(string(redhatopenshift.FipsValidatedModulesDisabled)),
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
 						},
 					},
 				},
 			},
 
-			"kubeadmin_username": {
-				Type:      schema.TypeString,
-				Computed:  true,
-				Optional:  true,
-				Sensitive: true,
-			},
-
-			"kubeadmin_password": {
-				Type:      schema.TypeString,
-				Computed:  true,
-				Optional:  true,
-				Sensitive: true,
-			},
-
-			"worker_profile": {
-				Type:     schema.TypeList,
-				Required: true,
-				MaxItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"vm_size": {
-							Type:             schema.TypeString,
-							Optional:         true,
-							ForceNew:         true,
-							Default:          StandardD4sV3,
-							DiffSuppressFunc: suppress.CaseDifference,
-							ValidateFunc:     validation.StringIsNotEmpty,
-						},
-						"disk_size_gb": {
-							Type:         schema.TypeInt,
-							Optional:     true,
-							ForceNew:     true,
-							Default:      128,
-							ValidateFunc: openShiftValidate.DiskSizeGB,
-						},
-						"node_count": {
-							Type:         schema.TypeInt,
-							Optional:     true,
-							ForceNew:     true,
-							Default:      3,
-							ValidateFunc: validation.IntBetween(3, 20),
+			"service_principal": schema.ListNestedBlock{
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, 1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"client_id": schema.StringAttribute{
+							Required: true,
+							Validators: []validator.String{
+								tf.StringValidator(openShiftValidate.ClientID),
+							},
 						},
-						"subnet_id": {
-							Type:         schema.TypeString,
-							Required:     true,
-							ValidateFunc: azure.ValidateResourceID,
+						"client_secret": schema.StringAttribute{
+							Optional:  true,
+							Sensitive: true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+								stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("client_secret_wo")),
+							},
 						},
-						"encryption_at_host": {
-							Type:         schema.TypeString,
-							Optional:     true,
-							Default:      redhatopenshift.EncryptionAtHostDisabled,
-							ValidateFunc: validation.StringIsNotEmpty,
+						"client_secret_wo": schema.StringAttribute{
+							Optional:  true,
+							Sensitive: true,
+							WriteOnly: true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
 						},
-						"disk_encryption_set": {
-							Type:     schema.TypeString,
+						"client_secret_wo_version": schema.Int64Attribute{
 							Optional: true,
+							Validators: []validator.Int64{
+								int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("client_secret_wo")),
+							},
 						},
 					},
 				},
 			},
 
-			"api_server_profile": {
-				Type:     schema.TypeList,
-				Optional: true,
-				ForceNew: true,
-				Computed: true,
-				MaxItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"visibility": {
-							Type:     schema.TypeString,
+			"network_profile": schema.ListNestedBlock{
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"pod_cidr": schema.StringAttribute{
 							Optional: true,
-							ForceNew: true,
-							Default:  APIPublic,
+							Computed: true,
+							Default:  stringdefault.StaticString("10.128.0.0/14"),
+							Validators: []validator.String{
+								tf.StringValidator(validate.CIDR),
+							},
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.RequiresReplace(),
+							},
 						},
-						"url": {
-							Type:     schema.TypeString,
+						"service_cidr": schema.StringAttribute{
+							Optional: true,
 							Computed: true,
+							Default:  stringdefault.StaticString("172.30.0.0/16"),
+							Validators: []validator.String{
+								tf.StringValidator(validate.CIDR),
+							},
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.RequiresReplace(),
+							},
 						},
-						"ip": {
-							Type:     schema.TypeString,
+						"outbound_type": schema.StringAttribute{
+							Optional: true,
 							Computed: true,
+							Default:  stringdefault.StaticString(string(redhatopenshift.OutboundTypeLoadbalancer)),
+							Validators: []validator.String{
+								tf.StringValidator(validate.ValidateOutBoundType),
+							},
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.RequiresReplace(),
+							},
 						},
 					},
 				},
 			},
 
-			"ingress_profile": {
-				Type:     schema.TypeList,
-				Optional: true,
-				ForceNew: true,
-				Computed: true,
-				MaxItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"visibility": {
-							Type:     schema.TypeString,
+			"master_profile": schema.ListNestedBlock{
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, 1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"subnet_id": schema.StringAttribute{
+							Required: true,
+							Validators: []validator.String{
+								tf.StringValidator(azure.ValidateResourceID),
+							},
+						},
+						"vm_size":             vmSizeAttribute(StandardD8sV3),
+						"encryption_at_host":  encryptionAtHostAttribute(),
+						"disk_encryption_set": diskEncryptionSetAttribute(),
+					},
+				},
+			},
+
+			"worker_profile": schema.ListNestedBlock{
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, 1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"vm_size": vmSizeAttribute(StandardD4sV3),
+						"disk_size_gb": schema.Int64Attribute{
 							Optional: true,
-							ForceNew: true,
-							Default:  redhatopenshift.VisibilityPublic,
+							Computed: true,
+							Default:  int64default.StaticInt64(128),
+							Validators: []validator.Int64{
+								tf.Int64Validator(openShiftValidate.DiskSizeGB),
+							},
+							PlanModifiers: []planmodifier.Int64{
+								int64planmodifier.RequiresReplace(),
+							},
 						},
-						"ip": {
-							Type:     schema.TypeString,
+						"node_count": schema.Int64Attribute{
+							Optional: true,
 							Computed: true,
+							Default:  int64default.StaticInt64(3),
+							Validators: []validator.Int64{
+								int64validator.Between(3, 20),
+							},
+							PlanModifiers: []planmodifier.Int64{
+								int64planmodifier.RequiresReplace(),
+							},
+						},
+						"subnet_id": schema.StringAttribute{
+							Required: true,
+							Validators: []validator.String{
+								tf.StringValidator(azure.ValidateResourceID),
+							},
 						},
+						"encryption_at_host":  encryptionAtHostAttribute(),
+						"disk_encryption_set": diskEncryptionSetAttribute(),
 					},
 				},
 			},
 
-			"version": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-
-			"console_url": {
-				Type:     schema.TypeString,
-				Computed: true,
+			"api_server_profile": schema.ListNestedBlock{
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"visibility": visibilityAttribute(),
+						"url":        computedStringAttribute(),
+						"ip":         computedStringAttribute(),
+					},
+				},
 			},
 
-			"tags": &schema.Schema{
-				Type:         schema.TypeMap,
-				Optional:     true,
-				ValidateFunc: azure.ValidateTags,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
+			"ingress_profile": schema.ListNestedBlock{
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"visibility": visibilityAttribute(),
+						"ip":         computedStringAttribute(),
+					},
 				},
 			},
+
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
 
-func resourceOpenShiftClusterCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).OpenShiftClustersClient
-	ctx, cancel := context.WithTimeout(meta.(*clients.Client).StopCtx, d.Timeout(schema.TimeoutCreate))
-	defer cancel()
+// locationAttribute is the location of a cluster, whose normalized form is kept in state.
+func locationAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Required: true,
+		Validators: []validator.String{
+			tf.StringValidator(location.EnhancedValidate),
+		},
+		PlanModifiers: []planmodifier.String{
+			tf.SuppressEquivalentString(func(old, new string) bool {
+				return location.Normalize(old) == location.Normalize(new)
+			}),
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
 
-	log.Printf("[INFO] preparing arguments for Red Hat Openshift Cluster create.")
+func resourceGroupNameAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Required: true,
+		Validators: []validator.String{
+			tf.StringValidator(resourcegroups.ValidateName),
+		},
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
 
-	resourceGroupName := d.Get("resource_group_name").(string)
-	subscriptionId := meta.(*clients.Client).SubscriptionID
+func tagsAttribute() schema.MapAttribute {
+	return schema.MapAttribute{
+		ElementType: types.StringType,
+		Optional:    true,
+		Validators: []validator.Map{
+			tf.MapValidator(azure.ValidateTags),
+		},
+	}
+}
 
-	name := d.Get("name").(string)
+// vmSizeAttribute is the size of the VMs of a profile, which Azure doesn't compare case sensitively.
+func vmSizeAttribute(defaultSize string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional: true,
+		Computed: true,
+		Default:  stringdefault.StaticString(defaultSize),
+		Validators: []validator.String{
+			stringvalidator.LengthAtLeast(1),
+		},
+		PlanModifiers: []planmodifier.String{
+			tf.SuppressEquivalentString(strings.EqualFold),
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
 
-	existing, err := client.Get(ctx, resourceGroupName, name, nil)
-	if err != nil {
-		responseError, ok := err.(*azcore.ResponseError)
-		if !ok || responseError.StatusCode != 404 {
-			return fmt.Errorf("checking for presence of existing Red Hat Openshift Cluster %q (Resource Group %q): %s", name, resourceGroupName, err)
-		}
+func encryptionAtHostAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional: true,
+		Computed: true,
+		Default:  stringdefault.StaticString(string(redhatopenshift.EncryptionAtHostDisabled)),
+		Validators: []validator.String{
+			stringvalidator.LengthAtLeast(1),
+		},
 	}
+}
 
-	if existing.ID != nil && *existing.ID != "" {
-		return tf.ImportAsExistsError("azurerm_redhatopenshift_cluster", *existing.ID)
+func diskEncryptionSetAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional: true,
 	}
+}
 
-	location := d.Get("location").(string)
+func visibilityAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional: true,
+		Computed: true,
+		Default:  stringdefault.StaticString(APIPublic),
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
 
-	clusterProfileRaw := d.Get("cluster_profile").([]interface{})
-	clusterResourceGroup := d.Get("cluster_resource_group").(string)
-	clusterProfile := aro.NewClusterProfileHelper(subscriptionId, clusterResourceGroup).Expand(clusterProfileRaw)
+func computedStringAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Computed: true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+}
 
-	consoleProfile := &redhatopenshift.ConsoleProfile{}
+func (r *openShiftClusterResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = openShiftClusterIdentitySchema()
+}
 
-	servicePrincipalProfileRaw := d.Get("service_principal").([]interface{})
-	servicePrincipalProfile := expandOpenshiftServicePrincipalProfile(servicePrincipalProfileRaw)
+func (r *openShiftClusterResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// the provider isn't configured yet when the configuration is validated
+	if req.ProviderData == nil {
+		return
+	}
 
-	networkProfileRaw := d.Get("network_profile").([]interface{})
-	networkProfile := expandOpenshiftNetworkProfile(networkProfileRaw)
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *clients.Client, got %T.", req.ProviderData))
+		return
+	}
 
-	masterProfileRaw := d.Get("master_profile").([]interface{})
-	masterProfile := expandOpenshiftMasterProfile(masterProfileRaw)
+	r.client = client
+}
 
-	workerProfilesRaw := d.Get("worker_profile").([]interface{})
-	workerProfiles := expandOpenshiftWorkerProfiles(workerProfilesRaw)
+func (r *openShiftClusterResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	if !req.ClientCapabilities.WriteOnlyAttributesAllowed {
+		return
+	}
 
-	apiServerProfileRaw := d.Get("api_server_profile").([]interface{})
-	apiServerProfile := expandOpenshiftApiServerProfile(apiServerProfileRaw)
+	var config openShiftClusterModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	ingressProfilesRaw := d.Get("ingress_profile").([]interface{})
-	ingressProfiles := expandOpenshiftIngressProfiles(ingressProfilesRaw)
+	resp.Diagnostics.Append(preferWriteOnlySecrets(ctx, config)...)
+}
 
-	t := d.Get("tags").(map[string]interface{})
+// ModifyPlan computes the cluster's domain and resource group when creating the cluster, so they are known at plan
+// time.  Both are derived from `name_seed`, which is kept in state so the values are stable.
+func (r *openShiftClusterResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// the cluster is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
 
-	parameters := redhatopenshift.OpenShiftCluster{
-		Name:     &name,
-		Location: &location,
-		Properties: &redhatopenshift.OpenShiftClusterProperties{
-			ClusterProfile:          clusterProfile,
-			ConsoleProfile:          consoleProfile,
-			ServicePrincipalProfile: servicePrincipalProfile,
-			NetworkProfile:          networkProfile,
-			MasterProfile:           masterProfile,
-			WorkerProfiles:          workerProfiles,
-			ApiserverProfile:        apiServerProfile,
-			IngressProfiles:         ingressProfiles,
-		},
-		Tags: azure.TagsExpand(t),
+	var config, plan openShiftClusterModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	var state *openShiftClusterModel
+	if !req.State.Raw.IsNull() {
+		state = &openShiftClusterModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	future, err := client.BeginCreateOrUpdate(ctx, resourceGroupName, name, parameters, nil)
-	if err != nil {
-		return fmt.Errorf("creating Red Hat OpenShift Cluster %q (Resource Group %q): %+v", name, resourceGroupName, err)
+	if state == nil {
+		seed, err := aro.NewSeed()
+		if err != nil {
+			resp.Diagnostics.AddError("Generating `name_seed`", err.Error())
+			return
+		}
+		plan.NameSeed = types.StringValue(seed)
 	}
 
-	if _, err = future.PollUntilDone(ctx, nil); err != nil {
-		return fmt.Errorf("waiting for creation of Red Hat OpenShift Cluster %q (Resource Group %q): %+v", name, resourceGroupName, err)
+	// the rest of the plan depends on the provider's configuration
+	if r.client == nil {
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
 	}
 
-	read, err := client.Get(ctx, resourceGroupName, name, nil)
-	if err != nil {
-		return fmt.Errorf("retrieving Red Hat OpenShift Cluster %q (Resource Group %q): %+v", name, resourceGroupName, err)
+	if state == nil {
+		resp.Diagnostics.Append(planOpenShiftClusterProfile(ctx, config, &plan, r.client.SubscriptionID)...)
 	}
 
-	if read.ID == nil {
-		return fmt.Errorf("cannot read ID for Red Hat OpenShift Cluster %q (Resource Group %q)", name, resourceGroupName)
+	resp.Diagnostics.Append(planManagedResourceGroupTags(ctx, &plan, state, r.client.TagsConfig)...)
+
+	if plan.Tags.IsUnknown() {
+		plan.TagsAll = types.MapUnknown(types.StringType)
+	} else {
+		tagsAll := r.client.TagsConfig.TagsAll(tagsMap(ctx, plan.Tags, &resp.Diagnostics))
+		if state != nil && azure.TagsEqual(tagsAll, tagsMap(ctx, state.TagsAll, &resp.Diagnostics)) {
+			plan.TagsAll = state.TagsAll
+		} else {
+			plan.TagsAll = tagsValue(ctx, tagsAll, &resp.Diagnostics)
+		}
 	}
 
-	d.SetId(*read.ID)
+	// updating the cluster's tags or secrets records the identifiers of the update
+	if state != nil && (!plan.TagsAll.Equal(state.TagsAll) || pullSecretChanged(ctx, plan, *state, &resp.Diagnostics) || clientSecretChanged(ctx, plan, *state, &resp.Diagnostics)) {
+		plan.LastOperation = types.ListUnknown(plan.LastOperation.ElementType(ctx))
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	return resourceOpenShiftClusterRead(d, meta)
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
 }
 
-func resourceOpenShiftClusterUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).OpenShiftClustersClient
-	ctx, cancel := context.WithTimeout(meta.(*clients.Client).StopCtx, d.Timeout(schema.TimeoutUpdate))
+// planOpenShiftClusterProfile plans the cluster profile of a new cluster, with its domain and resource group.  Each of
+// them is planned as soon as what it's derived from is known, whatever else is only known on apply.
+func planOpenShiftClusterProfile(ctx context.Context, config openShiftClusterModel, plan *openShiftClusterModel, subscriptionId string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// the profile is only kept in state when it's configured, and the number of profiles is only known on apply
+	configured := firstBlock[openShiftClusterProfileModel](ctx, config.ClusterProfile, &diags)
+	profile := firstBlock[openShiftClusterProfileModel](ctx, plan.ClusterProfile, &diags)
+	if diags.HasError() || configured == nil || profile == nil {
+		return diags
+	}
+
+	helper := aro.NewClusterProfileHelper(subscriptionId, plan.ClusterResourceGroup.ValueString(), plan.NameSeed.ValueString(), plan.DomainPrefix.ValueString())
+
+	if profile.Domain.IsUnknown() && !configured.Domain.IsUnknown() && !plan.DomainPrefix.IsUnknown() {
+		profile.Domain = types.StringValue(helper.Domain(""))
+	}
+
+	if profile.Version.IsUnknown() && !configured.Version.IsUnknown() {
+		profile.Version = types.StringValue("")
+	}
+
+	// a configured resource group is planned as configured
+	if configured.ResourceGroupId.IsNull() && !plan.ClusterResourceGroup.IsUnknown() {
+		profile.ResourceGroupId = types.StringValue(helper.ResourceGroupID(""))
+	}
+
+	plan.ClusterProfile = blockValue(ctx, config.ClusterProfile.ElementType(ctx), profile, &diags)
+
+	return diags
+}
+
+func (r *openShiftClusterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan openShiftClusterModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, 90*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.OpenShiftClustersClient
+	retryOptions := r.client.RetryOptions
+	ctx, cancel := context.WithTimeout(r.client.StopCtx, createTimeout)
 	defer cancel()
+	ctx, tracker := clients.WithOperationTracker(ctx)
 
-	log.Printf("[INFO] preparing arguments for Red Hat OpenShift Cluster update.")
+	log.Printf("[INFO] preparing arguments for Red Hat Openshift Cluster create.")
 
-	subscriptionId := meta.(*clients.Client).SubscriptionID
+	resourceGroupName := plan.ResourceGroupName.ValueString()
+	subscriptionId := r.client.SubscriptionID
 
-	id, err := parse.ClusterID(d.Id())
+	name := plan.Name.ValueString()
+
+	existing, err := clients.Retry(ctx, retryOptions, "retrieving Red Hat OpenShift Cluster", func() (redhatopenshift.OpenShiftClustersClientGetResponse, error) {
+		return client.Get(ctx, resourceGroupName, name)
+	})
 	if err != nil {
-		return err
+		if !utils.ResponseWasNotFound(err) {
+			resp.Diagnostics.Append(openShiftClusterDiagnostics(ctx, &plan, aroerrors.Diagnostics(fmt.Sprintf("checking for presence of existing Red Hat Openshift Cluster %q (Resource Group %q)", name, resourceGroupName), tracker.Wrap(err)))...)
+			return
+		}
 	}
 
-	d.Partial(true)
+	if existing.ID != nil && *existing.ID != "" {
+		resp.Diagnostics.AddError("Resource already exists", tf.ImportAsExistsError("azureopenshift_redhatopenshift_cluster", *existing.ID).Error())
+		return
+	}
+
+	properties, diags := expandOpenShiftClusterProperties(ctx, plan, subscriptionId)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	existing, err := client.Get(ctx, id.ResourceGroup, id.ManagedClusterName, nil)
+	pullSecret, diags := openShiftClusterPullSecret(ctx, req.Config, plan)
+	resp.Diagnostics.Append(diags...)
+	clientSecret, diags := openShiftClusterClientSecret(ctx, req.Config, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	properties.ClusterProfile.PullSecret = utils.String(pullSecret)
+	properties.ServicePrincipalProfile.ClientSecret = utils.String(clientSecret)
+
+	parameters := redhatopenshift.OpenShiftCluster{
+		Name:       &name,
+		Location:   plan.Location.ValueStringPointer(),
+		Properties: properties,
+		Tags:       azure.TagsExpand(tagsMap(ctx, plan.Tags, &resp.Diagnostics), r.client.TagsConfig),
+	}
+
+	virtualNetworkIds, err := openShiftVirtualNetworkIDs(*properties.MasterProfile.SubnetID, *properties.WorkerProfiles[0].SubnetID)
 	if err != nil {
-		return fmt.Errorf("retrieving existing Red Hat OpenShift Cluster %q (Resource Group %q): %+v", id.ManagedClusterName, id.ResourceGroup, err)
+		resp.Diagnostics.AddError("Invalid subnet ID", err.Error())
+		return
 	}
-	if existing.Properties == nil {
-		return fmt.Errorf("retrieving existing Red Hat OpenShift Cluster %q (Resource Group %q): `properties` was nil", id.ManagedClusterName, id.ResourceGroup)
+
+	createSemaphore := r.client.CreateSemaphore
+	if err := createSemaphore.Acquire(ctx); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("waiting to create Red Hat OpenShift Cluster %q (Resource Group %q)", name, resourceGroupName), err.Error())
+		return
+	}
+	defer createSemaphore.Release()
+
+	// ARO and ARM conflict when several clusters are created in the same virtual network at once
+	if err := locks.MultipleByID(ctx, virtualNetworkIds); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("waiting to create Red Hat OpenShift Cluster %q (Resource Group %q)", name, resourceGroupName), err.Error())
+		return
+	}
+	defer locks.UnlockMultipleByID(virtualNetworkIds)
+
+	future, err := clients.Retry(ctx, retryOptions, "creating Red Hat OpenShift Cluster", func() (clients.Poller[redhatopenshift.OpenShiftClustersClientCreateOrUpdateResponse], error) {
+		return client.BeginCreateOrUpdate(ctx, resourceGroupName, name, parameters)
+	})
+	if err != nil {
+		resp.Diagnostics.Append(openShiftClusterDiagnostics(ctx, &plan, aroerrors.Diagnostics(fmt.Sprintf("creating Red Hat OpenShift Cluster %q (Resource Group %q)", name, resourceGroupName), tracker.Wrap(err)))...)
+		return
 	}
 
-	if d.HasChange("cluster_profile") {
-		clusterProfileRaw := d.Get("cluster_profile").([]interface{})
-		clusterResourceGroup := d.Get("cluster_resource_group").(string)
-		clusterProfile := aro.NewClusterProfileHelper(subscriptionId, clusterResourceGroup).Expand(clusterProfileRaw)
-		existing.Properties.ClusterProfile = clusterProfile
+	if _, err = future.PollUntilDone(ctx, nil); err != nil {
+		resp.Diagnostics.Append(openShiftClusterDiagnostics(ctx, &plan, aroerrors.Diagnostics(fmt.Sprintf("waiting for creation of Red Hat OpenShift Cluster %q (Resource Group %q)", name, resourceGroupName), tracker.Wrap(err)))...)
+		return
 	}
+	operationIds := tracker.Ids()
 
-	if d.HasChange("master_profile") {
-		masterProfileRaw := d.Get("master_profile").([]interface{})
-		masterProfile := expandOpenshiftMasterProfile(masterProfileRaw)
-		existing.Properties.MasterProfile = masterProfile
+	read, err := clients.Retry(ctx, retryOptions, "retrieving Red Hat OpenShift Cluster", func() (redhatopenshift.OpenShiftClustersClientGetResponse, error) {
+		return client.Get(ctx, resourceGroupName, name)
+	})
+	if err != nil {
+		resp.Diagnostics.Append(openShiftClusterDiagnostics(ctx, &plan, aroerrors.Diagnostics(fmt.Sprintf("retrieving Red Hat OpenShift Cluster %q (Resource Group %q)", name, resourceGroupName), tracker.Wrap(err)))...)
+		return
 	}
 
-	if d.HasChange("worker_profile") {
-		workerProfilesRaw := d.Get("worker_profile").([]interface{})
-		workerProfiles := expandOpenshiftWorkerProfiles(workerProfilesRaw)
-		existing.Properties.WorkerProfiles = workerProfiles
+	if read.ID == nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("cannot read ID for Red Hat OpenShift Cluster %q (Resource Group %q)", name, resourceGroupName), "")
+		return
 	}
 
-	d.Partial(false)
+	// the cluster exists from here on, so it's kept in state even when the rest of its creation fails
+	plan.Id = types.StringValue(*read.ID)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), plan.Id)...)
+
+	plan.setLastOperation(ctx, operationIds, &resp.Diagnostics)
 
-	return resourceOpenShiftClusterRead(d, meta)
+	managedTags := tagsMap(ctx, plan.ManagedResourceGroupTagsApplied, &resp.Diagnostics)
+	if len(managedTags) > 0 && read.Properties != nil && read.Properties.ClusterProfile != nil && read.Properties.ClusterProfile.ResourceGroupID != nil {
+		// the cluster exists by now, so failing to tag its resources must not taint it: the tags found missing when
+		// the cluster is read are applied again on the next apply
+		for _, d := range updateManagedResourceGroupTags(ctx, r.client, *read.Properties.ClusterProfile.ResourceGroupID, nil, managedTags) {
+			resp.Diagnostics.AddWarning(d.Summary(), d.Detail())
+		}
+	}
+
+	if lockLevel := plan.LockLevel.ValueString(); lockLevel != "" {
+		// the cluster exists by now, so failing to lock it must not taint it: the missing lock is found when the
+		// cluster is refreshed and created by the next apply
+		if err := createClusterLock(ctx, r.client, plan.Id.ValueString(), lockLevel); err != nil {
+			resp.Diagnostics.AddWarning("Creating the management lock of the cluster", tracker.Wrap(err).Error())
+		}
+	}
+
+	// the planned lock level is kept even when the lock was refused, as Terraform expects it back
+	lockLevel := plan.LockLevel
+	found, diags := r.read(ctx, &plan, resp.Identity)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError(fmt.Sprintf("retrieving Red Hat OpenShift Cluster %q (Resource Group %q)", name, resourceGroupName), "The cluster was not found after its creation.")
+		return
+	}
+	plan.LockLevel = lockLevel
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
-func resourceOpenShiftClusterRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).OpenShiftClustersClient
-	ctx, cancel := context.WithTimeout(meta.(*clients.Client).StopCtx, d.Timeout(schema.TimeoutRead))
+func (r *openShiftClusterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state openShiftClusterModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 90*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.OpenShiftClustersClient
+	retryOptions := r.client.RetryOptions
+	ctx, cancel := context.WithTimeout(r.client.StopCtx, updateTimeout)
 	defer cancel()
+	ctx, tracker := clients.WithOperationTracker(ctx)
 
-	id, err := parse.ClusterID(d.Id())
+	log.Printf("[INFO] preparing arguments for Red Hat OpenShift Cluster update.")
+
+	id, err := parse.ClusterID(state.Id.ValueString())
 	if err != nil {
-		return err
+		resp.Diagnostics.AddError("Invalid cluster ID", err.Error())
+		return
 	}
 
-	resp, err := client.Get(ctx, id.ResourceGroup, id.ManagedClusterName, nil)
+	existing, err := clients.Retry(ctx, retryOptions, "retrieving Red Hat OpenShift Cluster", func() (redhatopenshift.OpenShiftClustersClientGetResponse, error) {
+		return client.Get(ctx, id.ResourceGroup, id.ManagedClusterName)
+	})
 	if err != nil {
-		responseError, ok := err.(*azcore.ResponseError)
-		if !ok || responseError.StatusCode != 404 {
-			return fmt.Errorf("checking for presence of existing Red Hat Openshift Cluster %q (Resource Group %q): %s", id.ManagedClusterName, id.ResourceGroup, err)
-		}
+		resp.Diagnostics.Append(openShiftClusterDiagnostics(ctx, &plan, aroerrors.Diagnostics(fmt.Sprintf("retrieving existing Red Hat OpenShift Cluster %q (Resource Group %q)", id.ManagedClusterName, id.ResourceGroup), tracker.Wrap(err)))...)
+		return
+	}
+	if existing.Properties == nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("retrieving existing Red Hat OpenShift Cluster %q (Resource Group %q)", id.ManagedClusterName, id.ResourceGroup), "`properties` was nil")
+		return
 	}
 
-	d.Set("name", resp.Name)
-	d.Set("resource_group_name", id.ResourceGroup)
-	d.Set("location", resp.Location)
+	unlockedLevel := ""
 
-	if props := resp.Properties; props != nil {
-		clusterProfile := flattenOpenShiftClusterProfile(props.ClusterProfile, d)
-		if err := d.Set("cluster_profile", clusterProfile); err != nil {
-			return fmt.Errorf("setting `cluster_profile`: %+v", err)
-		}
+	var parameters redhatopenshift.OpenShiftClusterUpdate
+	var updated []string
 
-		servicePrincipalProfile := flattenOpenShiftServicePrincipalProfile(props.ServicePrincipalProfile, d)
-		if err := d.Set("service_principal", servicePrincipalProfile); err != nil {
-			return fmt.Errorf("setting `service_principal`: %+v", err)
-		}
+	if !plan.TagsAll.Equal(state.TagsAll) {
+		tagsConfig := r.client.TagsConfig
+		tags := azure.TagsExpand(tagsMap(ctx, plan.Tags, &resp.Diagnostics), tagsConfig)
 
-		networkProfile := flattenOpenShiftNetworkProfile(props.NetworkProfile)
-		if err := d.Set("network_profile", networkProfile); err != nil {
-			return fmt.Errorf("setting `network_profile`: %+v", err)
+		// keep the ignored tags, such as those added by Azure Policy, as the update replaces all of the cluster's tags
+		for k, v := range existing.Tags {
+			if _, ok := tags[k]; !ok && tagsConfig.Ignored(k) {
+				tags[k] = v
+			}
 		}
 
-		masterProfile := flattenOpenShiftMasterProfile(props.MasterProfile)
-		if err := d.Set("master_profile", masterProfile); err != nil {
-			return fmt.Errorf("setting `master_profile`: %+v", err)
-		}
+		parameters.Tags = tags
+		updated = append(updated, "tags")
+	}
 
-		workerProfiles := flattenOpenShiftWorkerProfiles(props.WorkerProfiles)
-		if err := d.Set("worker_profile", workerProfiles); err != nil {
-			return fmt.Errorf("setting `worker_profile`: %+v", err)
-		}
+	properties, diags := expandOpenShiftClusterSecretsUpdate(ctx, req.Config, plan, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if properties != nil {
+		parameters.Properties = properties
+		updated = append(updated, "secrets")
+	}
+
+	if len(updated) > 0 {
+		description := strings.Join(updated, " and ")
 
-		apiServerProfile := flattenOpenShiftAPIServerProfile(props.ApiserverProfile)
-		if err := d.Set("api_server_profile", apiServerProfile); err != nil {
-			return fmt.Errorf("setting `api_server_profile`: %+v", err)
+		// a ReadOnly lock refuses any update of the cluster, so it's lifted for the duration of the update
+		if state.LockLevel.ValueString() == string(armlocks.LockLevelReadOnly) {
+			unlockedLevel = state.LockLevel.ValueString()
 		}
 
-		ingressProfiles := flattenOpenShiftIngressProfiles(props.IngressProfiles)
-		if err := d.Set("ingress_profile", ingressProfiles); err != nil {
-			return fmt.Errorf("setting `ingress_profile`: %+v", err)
+		resp.Diagnostics.Append(withoutClusterLock(ctx, r.client, tracker, id.ID(), unlockedLevel, func() diag.Diagnostics {
+			future, err := clients.Retry(ctx, retryOptions, "updating Red Hat OpenShift Cluster", func() (clients.Poller[redhatopenshift.OpenShiftClustersClientUpdateResponse], error) {
+				return client.BeginUpdate(ctx, id.ResourceGroup, id.ManagedClusterName, parameters)
+			})
+			if err != nil {
+				return openShiftClusterDiagnostics(ctx, &plan, aroerrors.Diagnostics(fmt.Sprintf("updating %s of Red Hat OpenShift Cluster %q (Resource Group %q)", description, id.ManagedClusterName, id.ResourceGroup), tracker.Wrap(err)))
+			}
+
+			if _, err = future.PollUntilDone(ctx, nil); err != nil {
+				return openShiftClusterDiagnostics(ctx, &plan, aroerrors.Diagnostics(fmt.Sprintf("waiting for update of %s of Red Hat OpenShift Cluster %q (Resource Group %q)", description, id.ManagedClusterName, id.ResourceGroup), tracker.Wrap(err)))
+			}
+
+			return nil
+		})...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
 
-		d.Set("version", props.ClusterProfile.Version)
-		d.Set("console_url", props.ConsoleProfile.URL)
+		plan.setLastOperation(ctx, tracker.Ids(), &resp.Diagnostics)
 	}
 
-	credResponse, err := client.ListCredentials(ctx, id.ResourceGroup, id.ManagedClusterName, nil)
-	if err != nil {
-		responseError, ok := err.(*azcore.ResponseError)
-		if !ok || responseError.StatusCode != 404 {
-			return fmt.Errorf("checking for presence of existing Red Hat Openshift Cluster %q (Resource Group %q): %s", id.ManagedClusterName, id.ResourceGroup, err)
+	// the plan can't tell whether a pull secret being removed is sent to the cluster, in which case it isn't
+	if plan.LastOperation.IsUnknown() {
+		plan.LastOperation = state.LastOperation
+	}
+
+	if !plan.LockLevel.Equal(state.LockLevel) || unlockedLevel != "" {
+		if lockLevel := plan.LockLevel.ValueString(); lockLevel != "" {
+			if err := createClusterLock(ctx, r.client, id.ID(), lockLevel); err != nil {
+				resp.Diagnostics.AddError("Creating the management lock of the cluster", tracker.Wrap(err).Error())
+				return
+			}
+		} else if err := deleteClusterLock(ctx, r.client, id.ID()); err != nil {
+			resp.Diagnostics.AddError("Deleting the management lock of the cluster", tracker.Wrap(err).Error())
+			return
 		}
-	} else {
-		d.Set("kubeadmin_username", credResponse.KubeadminUsername)
-		d.Set("kubeadmin_password", credResponse.KubeadminPassword)
 	}
 
-	return azure.TagsFlattenAndSet(d, resp.Tags)
-}
+	// the resource group is read from the cluster, as `cluster_profile` is only kept in state when it's configured
+	if !plan.ManagedResourceGroupTagsApplied.Equal(state.ManagedResourceGroupTagsApplied) {
+		if profile := existing.Properties.ClusterProfile; profile != nil && profile.ResourceGroupID != nil {
+			oldTags := tagsMap(ctx, state.ManagedResourceGroupTagsApplied, &resp.Diagnostics)
+			newTags := tagsMap(ctx, plan.ManagedResourceGroupTagsApplied, &resp.Diagnostics)
+			resp.Diagnostics.Append(updateManagedResourceGroupTags(ctx, r.client, *profile.ResourceGroupID, oldTags, newTags)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+	}
 
-func resourceOpenShiftClusterDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).OpenShiftClustersClient
-	ctx, cancel := context.WithTimeout(meta.(*clients.Client).StopCtx, d.Timeout(schema.TimeoutDelete))
-	defer cancel()
+	// the prior values are kept when the cluster is read, such as those of the secrets which the API doesn't return
+	found, diags := r.read(ctx, &plan, resp.Identity)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError(fmt.Sprintf("retrieving Red Hat OpenShift Cluster %q (Resource Group %q)", id.ManagedClusterName, id.ResourceGroup), "The cluster was not found after its update.")
+		return
+	}
 
-	id, err := parse.ClusterID(d.Id())
-	if err != nil {
-		return err
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *openShiftClusterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state openShiftClusterModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	future, err := client.BeginDelete(ctx, id.ResourceGroup, id.ManagedClusterName, nil)
-	if err != nil {
-		return fmt.Errorf("deleting Red Hat Openshift Cluster %q (Resource Group %q): %+v", id.ManagedClusterName, id.ResourceGroup, err)
+	readTimeout, diags := state.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if _, err := future.PollUntilDone(ctx, nil); err != nil {
-		return fmt.Errorf("waiting for the deletion of Red Hat Openshift Cluster %q (Resource Group %q): %+v", id.ManagedClusterName, id.ResourceGroup, err)
+	ctx, cancel := context.WithTimeout(r.client.StopCtx, readTimeout)
+	defer cancel()
+
+	found, diags := r.read(ctx, &state, resp.Identity)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
 	}
 
-	return nil
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
-func flattenOpenShiftClusterProfile(profile *redhatopenshift.ClusterProfile, d *schema.ResourceData) []interface{} {
-	if profile == nil {
-		return []interface{}{}
+// read refreshes the model from the cluster, returning false when the cluster no longer exists.
+func (r *openShiftClusterResource) read(ctx context.Context, model *openShiftClusterModel, identity *tfsdk.ResourceIdentity) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	client := r.client.OpenShiftClustersClient
+	credentialsClient := r.client.OpenShiftCredentialsClient
+	retryOptions := r.client.RetryOptions
+	ctx, tracker := clients.WithOperationTracker(ctx)
+
+	id, err := parse.ClusterID(model.Id.ValueString())
+	if err != nil {
+		diags.AddError("Invalid cluster ID", err.Error())
+		return true, diags
 	}
 
-	// pull secret isn't returned by the API so pass the existing value along
-	var pullSecret interface{}
+	// the identity is set even when the cluster is gone, as Terraform expects one from every read
+	diags.Append(setOpenShiftClusterIdentity(ctx, identity, *id)...)
 
-	var version interface{}
-	clusterProfileRaw := d.Get("cluster_profile").([]interface{})
-	if len(clusterProfileRaw) != 0 {
-		pullSecretRaw := d.Get("cluster_profile").([]interface{})[0].(map[string]interface{})["pull_secret"]
-		if pullSecretRaw != nil {
-			pullSecret = pullSecretRaw.(string)
+	resp, err := clients.Retry(ctx, retryOptions, "retrieving Red Hat OpenShift Cluster", func() (redhatopenshift.OpenShiftClustersClientGetResponse, error) {
+		return client.Get(ctx, id.ResourceGroup, id.ManagedClusterName)
+	})
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			log.Printf("[DEBUG] Red Hat OpenShift Cluster %q was not found in Resource Group %q - removing from state!", id.ManagedClusterName, id.ResourceGroup)
+			return false, nil
 		}
+		diags.Append(openShiftClusterDiagnostics(ctx, model, aroerrors.Diagnostics(fmt.Sprintf("retrieving Red Hat Openshift Cluster %q (Resource Group %q)", id.ManagedClusterName, id.ResourceGroup), tracker.Wrap(err)))...)
+		return true, diags
+	}
 
-		versionRaw := d.Get("cluster_profile").([]interface{})[0].(map[string]interface{})["version"]
-		if versionRaw != nil {
-			version = versionRaw.(string)
+	diags.Append(model.flatten(ctx, *id, resp.OpenShiftCluster, r.client.TagsConfig)...)
+	if diags.HasError() {
+		return true, diags
+	}
+
+	if props := resp.Properties; props != nil && props.ClusterProfile != nil && props.ClusterProfile.ResourceGroupID != nil {
+		applied := tagsMap(ctx, model.ManagedResourceGroupTagsApplied, &diags)
+		// the tags found are only compared with those applied, so failing to read them, such as when the deny
+		// assignment forbids it, keeps those applied rather than failing the refresh of the cluster
+		managedTags, err := flattenManagedResourceGroupTags(ctx, r.client, *props.ClusterProfile.ResourceGroupID, applied)
+		if err != nil {
+			diags.AddWarning("Reading the tags of the managed resource group", err.Error())
+		} else {
+			model.ManagedResourceGroupTagsApplied = tagsValue(ctx, managedTags, &diags)
 		}
 	}
 
-	clusterDomain := ""
-	if profile.Domain != nil {
-		clusterDomain = *profile.Domain
+	// the lock is only read when it's managed, as reading it needs permissions which managing the cluster doesn't, and
+	// its level is left unchanged when those permissions are missing
+	lockLevel := model.LockLevel.ValueString()
+	if !model.LockLevel.IsNull() {
+		level, err := readClusterLock(ctx, r.client, id.ID())
+		if err != nil {
+			if !utils.ResponseWasForbidden(err) {
+				diags.AddError("Reading the management lock of the cluster", err.Error())
+				return true, diags
+			}
+			log.Printf("[DEBUG] not allowed to read the management lock of Red Hat OpenShift Cluster %q (Resource Group %q) - keeping its level unchanged: %+v", id.ManagedClusterName, id.ResourceGroup, err)
+		} else {
+			lockLevel = level
+			model.LockLevel = types.StringValue(level)
+		}
 	}
 
-	resourceGroupId := ""
-	if profile.ResourceGroupID != nil {
-		resourceGroupId = *profile.ResourceGroupID
+	credResponse, err := clients.Retry(ctx, retryOptions, "listing credentials for Red Hat OpenShift Cluster", func() (redhatopenshift.OpenShiftClustersClientListCredentialsResponse, error) {
+		return credentialsClient.ListCredentials(ctx, id.ResourceGroup, id.ManagedClusterName)
+	})
+	if err != nil {
+		// listing the credentials is a POST, which a ReadOnly management lock refuses
+		if lockLevel == string(armlocks.LockLevelReadOnly) && utils.ResponseWasConflict(err) {
+			log.Printf("[DEBUG] the ReadOnly management lock of Red Hat OpenShift Cluster %q (Resource Group %q) prevents listing its credentials - keeping them unchanged", id.ManagedClusterName, id.ResourceGroup)
+		} else if !utils.ResponseWasNotFound(err) {
+			diags.Append(openShiftClusterDiagnostics(ctx, model, aroerrors.Diagnostics(fmt.Sprintf("listing credentials for Red Hat Openshift Cluster %q (Resource Group %q)", id.ManagedClusterName, id.ResourceGroup), tracker.Wrap(err)))...)
+			return true, diags
+		}
+	} else {
+		model.KubeadminUsername = stringValue(credResponse.KubeadminUsername)
+		model.KubeadminPassword = stringValue(credResponse.KubeadminPassword)
 	}
 
-	return []interface{}{
-		map[string]interface{}{
-			"pull_secret":            pullSecret,
-			"domain":                 clusterDomain,
-			"resource_group_id":      resourceGroupId,
-			"fips_validated_modules": profile.FipsValidatedModules,
-			"version":                version,
-		},
+	// the values are left unknown when they're only computed by the plan, e.g. on import
+	if model.KubeadminUsername.IsUnknown() {
+		model.KubeadminUsername = types.StringNull()
 	}
+	if model.KubeadminPassword.IsUnknown() {
+		model.KubeadminPassword = types.StringNull()
+	}
+
+	return true, diags
 }
 
-func flattenOpenShiftServicePrincipalProfile(profile *redhatopenshift.ServicePrincipalProfile, d *schema.ResourceData) []interface{} {
-	if profile == nil {
-		return []interface{}{}
+// setLastOperation records the identifiers of the operation which created or updated the cluster.
+func (m *openShiftClusterModel) setLastOperation(ctx context.Context, ids clients.OperationIds, diags *diag.Diagnostics) {
+	m.LastOperation = blockValue(ctx, m.LastOperation.ElementType(ctx), &openShiftOperationModel{
+		RequestId:            types.StringValue(ids.RequestId),
+		CorrelationRequestId: types.StringValue(ids.CorrelationRequestId),
+		AsyncOperationUrl:    types.StringValue(ids.AsyncOperationUrl),
+	}, diags)
+}
+
+// openShiftClusterDiagnostics converts the diagnostics of the API errors, pointing them at the attributes of the
+// cluster described by model, which is nil when it isn't known.  Errors about the client secret point at
+// `client_secret_wo` when it's the one configured.
+func openShiftClusterDiagnostics(ctx context.Context, model *openShiftClusterModel, diags []aroerrors.Diagnostic) diag.Diagnostics {
+	writeOnlyClientSecret := false
+	if model != nil {
+		if sp := firstBlock[openShiftServicePrincipalModel](ctx, model.ServicePrincipal, &diag.Diagnostics{}); sp != nil {
+			writeOnlyClientSecret = sp.ClientSecret.IsNull()
+		}
 	}
 
-	clientID := ""
-	if profile.ClientID != nil {
-		clientID = *profile.ClientID
+	return apiErrorDiagnostics(diags, func(attribute []string) (path.Path, bool) {
+		if writeOnlyClientSecret && slices.Equal(attribute, []string{"service_principal", "client_secret"}) {
+			return clientSecretWriteOnlyPath, true
+		}
+
+		return blockAttributePath(attribute), true
+	})
+}
+
+// flatten sets the attributes read from the cluster, keeping those which the API doesn't return, such as the secrets,
+// from the model.
+func (m *openShiftClusterModel) flatten(ctx context.Context, id parse.ClusterId, cluster redhatopenshift.OpenShiftCluster, tagsConfig azure.TagsConfig) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// only the ID is known when the cluster is imported
+	imported := m.Name.IsNull()
+
+	m.Id = types.StringValue(id.ID())
+	m.Name = stringValue(cluster.Name)
+	m.ResourceGroupName = types.StringValue(id.ResourceGroup)
+	m.Location = locationValue(m.Location, cluster.Location)
+
+	// the attributes with a default are null when the cluster is imported
+	if m.DeletionProtection.IsNull() {
+		m.DeletionProtection = types.BoolValue(false)
 	}
+	if m.PropagateTagsToManagedResourceGroup.IsNull() {
+		m.PropagateTagsToManagedResourceGroup = types.BoolValue(false)
+	}
+
+	if props := cluster.Properties; props != nil {
+		// the optional blocks are only kept when they're configured, or all of them when the cluster is imported, as
+		// Terraform plans the removal of those which aren't
+		if imported || len(m.ClusterProfile.Elements()) > 0 {
+			clusterProfile := flattenOpenShiftClusterProfile(props.ClusterProfile, firstBlock[openShiftClusterProfileModel](ctx, m.ClusterProfile, &diags))
+			m.ClusterProfile = blockValue(ctx, m.ClusterProfile.ElementType(ctx), clusterProfile, &diags)
+		}
+		if imported || len(m.NetworkProfile.Elements()) > 0 {
+			m.NetworkProfile = blockValue(ctx, m.NetworkProfile.ElementType(ctx), flattenOpenShiftNetworkProfile(props.NetworkProfile), &diags)
+		}
+		if imported || len(m.ApiServerProfile.Elements()) > 0 {
+			m.ApiServerProfile = blockValue(ctx, m.ApiServerProfile.ElementType(ctx), flattenOpenShiftAPIServerProfile(props.ApiserverProfile), &diags)
+		}
+		if imported || len(m.IngressProfile.Elements()) > 0 {
+			m.IngressProfile = blockValue(ctx, m.IngressProfile.ElementType(ctx), flattenOpenShiftIngressProfiles(props.IngressProfiles), &diags)
+		}
 
-	// client secret isn't returned by the API so pass the existing value along
-	clientSecret := ""
-	if sp, ok := d.GetOk("service_principal"); ok {
-		var val []interface{}
+		servicePrincipal := flattenOpenShiftServicePrincipalProfile(props.ServicePrincipalProfile, firstBlock[openShiftServicePrincipalModel](ctx, m.ServicePrincipal, &diags))
+		m.ServicePrincipal = blockValue(ctx, m.ServicePrincipal.ElementType(ctx), servicePrincipal, &diags)
 
-		// prior to 1.34 this was a *pluginsdk.Set, now it's a List - try both
-		if v, ok := sp.([]interface{}); ok {
-			val = v
-		} else if v, ok := sp.(*schema.Set); ok {
-			val = v.List()
+		m.MasterProfile = blockValue(ctx, m.MasterProfile.ElementType(ctx), flattenOpenShiftMasterProfile(props.MasterProfile), &diags)
+		m.WorkerProfile = blockValue(ctx, m.WorkerProfile.ElementType(ctx), flattenOpenShiftWorkerProfiles(props.WorkerProfiles), &diags)
+
+		m.Version = types.StringValue("")
+		if props.ClusterProfile != nil {
+			m.Version = stringValue(props.ClusterProfile.Version)
+		}
+		m.ConsoleUrl = types.StringValue("")
+		if props.ConsoleProfile != nil {
+			m.ConsoleUrl = stringValue(props.ConsoleProfile.URL)
 		}
+	}
 
-		if len(val) > 0 && val[0] != nil {
-			raw := val[0].(map[string]interface{})
-			clientSecret = raw["client_secret"].(string)
+	// the computed values which the cluster didn't have are left empty
+	for _, v := range []*types.String{&m.NameSeed, &m.Version, &m.ConsoleUrl} {
+		if v.IsUnknown() {
+			*v = types.StringNull()
 		}
 	}
+	if m.LastOperation.IsUnknown() {
+		m.LastOperation = types.ListNull(m.LastOperation.ElementType(ctx))
+	}
+	if m.ManagedResourceGroupTagsApplied.IsUnknown() {
+		m.ManagedResourceGroupTagsApplied = types.MapNull(types.StringType)
+	}
 
-	return []interface{}{
-		map[string]interface{}{
-			"client_id":     clientID,
-			"client_secret": clientSecret,
-		},
+	tags, tagsAll := azure.TagsFlatten(cluster.Tags, tagsMap(ctx, m.Tags, &diags), tagsConfig)
+	if !m.Tags.IsNull() || len(tags) > 0 {
+		m.Tags = tagsValue(ctx, tags, &diags)
 	}
+	m.TagsAll = tagsValue(ctx, tagsAll, &diags)
+
+	return diags
 }
 
-func flattenOpenShiftNetworkProfile(profile *redhatopenshift.NetworkProfile) []interface{} {
-	if profile == nil {
-		return []interface{}{}
+func (r *openShiftClusterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state openShiftClusterModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	podCidr := ""
-	if profile.PodCidr != nil {
-		podCidr = *profile.PodCidr
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 90*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	serviceCidr := ""
-	if profile.ServiceCidr != nil {
-		serviceCidr = *profile.ServiceCidr
-	}
+	client := r.client.OpenShiftClustersClient
+	retryOptions := r.client.RetryOptions
+	ctx, cancel := context.WithTimeout(r.client.StopCtx, deleteTimeout)
+	defer cancel()
+	ctx, tracker := clients.WithOperationTracker(ctx)
 
-	var outboundType *redhatopenshift.OutboundType
-	if profile.OutboundType != nil {
-		outboundType = profile.OutboundType
+	id, err := parse.ClusterID(state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid cluster ID", err.Error())
+		return
 	}
 
-	return []interface{}{
-		map[string]interface{}{
-			"pod_cidr":      podCidr,
-			"service_cidr":  serviceCidr,
-			"outbound_type": outboundType,
-		},
+	var subnetIds []string
+	if profile := firstBlock[openShiftMasterProfileModel](ctx, state.MasterProfile, &resp.Diagnostics); profile != nil {
+		subnetIds = append(subnetIds, profile.SubnetId.ValueString())
+	}
+	if profile := firstBlock[openShiftWorkerProfileModel](ctx, state.WorkerProfile, &resp.Diagnostics); profile != nil {
+		subnetIds = append(subnetIds, profile.SubnetId.ValueString())
+	}
+	virtualNetworkIds, err := openShiftVirtualNetworkIDs(subnetIds...)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid subnet ID", err.Error())
+		return
 	}
-}
 
-func flattenOpenShiftMasterProfile(profile *redhatopenshift.MasterProfile) []interface{} {
-	if profile == nil {
-		return []interface{}{}
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddAttributeError(path.Root("deletion_protection"),
+			fmt.Sprintf("deleting Red Hat OpenShift Cluster %q (Resource Group %q): deletion protection is enabled", id.ManagedClusterName, id.ResourceGroup),
+			"The cluster has `deletion_protection` enabled, so it was not deleted. This includes replacing the "+
+				"cluster because of a change to an argument which forces a new cluster. Apply `deletion_protection = false` "+
+				"first to delete or replace the cluster.")
+		return
 	}
 
-	subnetId := ""
-	if profile.SubnetID != nil {
-		subnetId = *profile.SubnetID
+	exists, diags := checkDeletionProtectionTag(ctx, r.client, *id, tracker)
+	resp.Diagnostics.Append(diags...)
+	if !exists || resp.Diagnostics.HasError() {
+		return
 	}
 
-	return []interface{}{
-		map[string]interface{}{
-			"vm_size":             profile.VMSize,
-			"subnet_id":           subnetId,
-			"disk_encryption_set": profile.DiskEncryptionSetID,
-			"encryption_at_host":  profile.EncryptionAtHost,
-		},
+	if err := locks.MultipleByID(ctx, virtualNetworkIds); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("waiting to delete Red Hat OpenShift Cluster %q (Resource Group %q)", id.ManagedClusterName, id.ResourceGroup), err.Error())
+		return
 	}
-}
+	defer locks.UnlockMultipleByID(virtualNetworkIds)
+
+	resp.Diagnostics.Append(withoutClusterLock(ctx, r.client, tracker, id.ID(), state.LockLevel.ValueString(), func() diag.Diagnostics {
+		future, err := clients.Retry(ctx, retryOptions, "deleting Red Hat OpenShift Cluster", func() (clients.Poller[redhatopenshift.OpenShiftClustersClientDeleteResponse], error) {
+			return client.BeginDelete(ctx, id.ResourceGroup, id.ManagedClusterName)
+		})
+		if err != nil {
+			return openShiftClusterDiagnostics(ctx, &state, aroerrors.Diagnostics(fmt.Sprintf("deleting Red Hat Openshift Cluster %q (Resource Group %q)", id.ManagedClusterName, id.ResourceGroup), tracker.Wrap(err)))
+		}
 
-func flattenOpenShiftWorkerProfiles(profiles []*redhatopenshift.WorkerProfile) []interface{} {
+		if _, err := future.PollUntilDone(ctx, nil); err != nil {
+			return openShiftClusterDiagnostics(ctx, &state, aroerrors.Diagnostics(fmt.Sprintf("waiting for the deletion of Red Hat Openshift Cluster %q (Resource Group %q)", id.ManagedClusterName, id.ResourceGroup), tracker.Wrap(err)))
+		}
 
-	// Expect the default worker profile only has one item
-	if profiles == nil || len(profiles) != 1 {
-		return []interface{}{}
-	}
+		return nil
+	})...)
+}
 
-	results := make([]interface{}, 0)
+func (r *openShiftClusterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importOpenShiftCluster(ctx, r.client, req, resp)
+}
 
-	result := make(map[string]interface{})
-	result["node_count"] = profiles[0].Count
+// checkDeletionProtectionTag refuses to delete the cluster when it's tagged with the provider's
+// `deletion_protection_tag`.  It returns false when the cluster no longer exists.
+func checkDeletionProtectionTag(ctx context.Context, client *clients.Client, id parse.ClusterId, tracker *clients.OperationTracker) (bool, diag.Diagnostics) {
+	tag := client.DeletionProtectionTag
+	if tag == "" {
+		return true, nil
+	}
 
-	for _, profile := range profiles {
-		if result["disk_size_gb"] == nil && profile.DiskSizeGB != nil {
-			result["disk_size_gb"] = profile.DiskSizeGB
+	existing, err := clients.Retry(ctx, client.RetryOptions, "retrieving Red Hat OpenShift Cluster", func() (redhatopenshift.OpenShiftClustersClientGetResponse, error) {
+		return client.OpenShiftClustersClient.Get(ctx, id.ResourceGroup, id.ManagedClusterName)
+	})
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			return false, nil
 		}
+		return true, openShiftClusterDiagnostics(ctx, nil, aroerrors.Diagnostics(fmt.Sprintf("retrieving Red Hat Openshift Cluster %q (Resource Group %q)", id.ManagedClusterName, id.ResourceGroup), tracker.Wrap(err)))
+	}
 
-		vmSize := profile.VMSize
+	if _, ok := existing.Tags[tag]; ok {
+		return true, diag.Diagnostics{diag.NewErrorDiagnostic(
+			fmt.Sprintf("deleting Red Hat OpenShift Cluster %q (Resource Group %q): the cluster is protected by the %q tag", id.ManagedClusterName, id.ResourceGroup, tag),
+			fmt.Sprintf("The provider's `deletion_protection_tag` refuses to delete clusters tagged with %q, "+
+				"so the cluster was not deleted. Remove the tag from the cluster in Azure first to delete or replace it.", tag),
+		)}
+	}
 
-		if result["vm_size"] == nil && *vmSize != "" {
-			result["vm_size"] = vmSize
-		}
+	return true, nil
+}
 
-		if result["subnet_id"] == nil && profile.SubnetID != nil {
-			result["subnet_id"] = profile.SubnetID
+// openShiftVirtualNetworkIDs returns the IDs of the virtual networks containing the subnets, which are locked whilst
+// the cluster is created or deleted.
+func openShiftVirtualNetworkIDs(subnetIds ...string) ([]string, error) {
+	ids := make([]string, 0)
+	for _, subnetId := range subnetIds {
+		if subnetId == "" {
+			continue
 		}
-		if result["disk_encryption_set"] == nil && profile.DiskEncryptionSetID != nil {
-			result["disk_encryption_set"] = profile.DiskEncryptionSetID
+
+		id, err := parse.SubnetID(subnetId)
+		if err != nil {
+			return nil, fmt.Errorf("parsing subnet ID %q: %+v", subnetId, err)
 		}
-		result["encryption_at_host"] = profile.EncryptionAtHost
+		ids = append(ids, id.VirtualNetworkID())
 	}
 
-	results = append(results, result)
+	return ids, nil
+}
+
+// expandOpenShiftClusterProperties expands the profiles of the cluster, without its secrets.
+func expandOpenShiftClusterProperties(ctx context.Context, plan openShiftClusterModel, subscriptionId string) (*redhatopenshift.OpenShiftClusterProperties, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	clusterProfile := firstBlock[openShiftClusterProfileModel](ctx, plan.ClusterProfile, &diags)
+	clusterResourceGroup := plan.ClusterResourceGroup.ValueString()
+	nameSeed := plan.NameSeed.ValueString()
+
+	servicePrincipal := firstBlock[openShiftServicePrincipalModel](ctx, plan.ServicePrincipal, &diags)
+	masterProfile := firstBlock[openShiftMasterProfileModel](ctx, plan.MasterProfile, &diags)
+	workerProfile := firstBlock[openShiftWorkerProfileModel](ctx, plan.WorkerProfile, &diags)
+	if diags.HasError() {
+		return nil, diags
+	}
+	if servicePrincipal == nil || masterProfile == nil || workerProfile == nil {
+		diags.AddError("Invalid configuration", "`service_principal`, `master_profile` and `worker_profile` are required.")
+		return nil, diags
+	}
 
-	return results
+	properties := &redhatopenshift.OpenShiftClusterProperties{
+		ClusterProfile:          aro.NewClusterProfileHelper(subscriptionId, clusterResourceGroup, nameSeed, plan.DomainPrefix.ValueString()).Expand(clusterProfile.raw()),
+		ConsoleProfile:          &redhatopenshift.ConsoleProfile{},
+		ServicePrincipalProfile: expandOpenshiftServicePrincipalProfile(servicePrincipal.ClientId.ValueString(), servicePrincipal.ClientSecret.ValueString()),
+		NetworkProfile:          expandOpenshiftNetworkProfile(firstBlock[openShiftNetworkProfileModel](ctx, plan.NetworkProfile, &diags)),
+		MasterProfile:           expandOpenshiftMasterProfile(masterProfile),
+		WorkerProfiles:          expandOpenshiftWorkerProfiles(workerProfile),
+		ApiserverProfile:        expandOpenshiftApiServerProfile(firstBlock[openShiftAPIServerProfileModel](ctx, plan.ApiServerProfile, &diags)),
+		IngressProfiles:         expandOpenshiftIngressProfiles(firstBlock[openShiftIngressProfileModel](ctx, plan.IngressProfile, &diags)),
+	}
+
+	return properties, diags
 }
 
-func flattenOpenShiftAPIServerProfile(profile *redhatopenshift.APIServerProfile) []interface{} {
-	if profile == nil {
-		return []interface{}{}
+// raw returns the profile in the shape expanded by aro.ClusterProfileHelper, or nil when there's no profile.
+func (m *openShiftClusterProfileModel) raw() []interface{} {
+	if m == nil {
+		return nil
 	}
 
 	return []interface{}{
 		map[string]interface{}{
-			"visibility": string(*profile.Visibility),
-			"url":        string(*profile.URL),
-			"ip":         string(*profile.IP),
+			"pull_secret":            m.PullSecret.ValueString(),
+			"domain":                 m.Domain.ValueString(),
+			"version":                m.Version.ValueString(),
+			"resource_group_id":      m.ResourceGroupId.ValueString(),
+			"fips_validated_modules": m.FipsValidatedModules.ValueString(),
 		},
 	}
 }
 
-func flattenOpenShiftIngressProfiles(profiles []*redhatopenshift.IngressProfile) []interface{} {
-	if profiles == nil {
-		return []interface{}{}
+// flattenOpenShiftClusterProfile flattens the profile, keeping the pull secret and version of the prior profile as
+// the API doesn't return the pull secret.
+func flattenOpenShiftClusterProfile(profile *redhatopenshift.ClusterProfile, prior *openShiftClusterProfileModel) *openShiftClusterProfileModel {
+	if profile == nil {
+		return nil
 	}
 
-	results := make([]interface{}, 0)
+	pullSecret := types.StringNull()
+	pullSecretWriteOnlyVersion := types.Int64Null()
+	version := types.StringNull()
+	if prior != nil {
+		pullSecret = prior.PullSecret
+		pullSecretWriteOnlyVersion = prior.PullSecretWriteOnlyVersion
+		version = prior.Version
+	}
+	if version.IsUnknown() {
+		version = stringValue(profile.Version)
+	}
 
-	for _, profile := range profiles {
-		result := make(map[string]interface{})
-		result["visibility"] = string(*profile.Visibility)
-		result["ip"] = string(*profile.IP)
+	return &openShiftClusterProfileModel{
+		PullSecret:                 pullSecret,
+		PullSecretWriteOnly:        types.StringNull(),
+		PullSecretWriteOnlyVersion: pullSecretWriteOnlyVersion,
+		Domain:                     stringValue(profile.Domain),
+		Version:                    version,
+		ResourceGroupId:            stringValue(profile.ResourceGroupID),
+		FipsValidatedModules:       stringValue(profile.FipsValidatedModules),
+	}
+}
 
-		results = append(results, result)
+// flattenOpenShiftServicePrincipalProfile flattens the profile, keeping the client secret of the prior profile, with
+// the version of the write-only one, as the API doesn't return it.
+func flattenOpenShiftServicePrincipalProfile(profile *redhatopenshift.ServicePrincipalProfile, prior *openShiftServicePrincipalModel) *openShiftServicePrincipalModel {
+	if profile == nil {
+		return nil
 	}
 
-	return results
+	flattened := &openShiftServicePrincipalModel{
+		ClientId:                     stringValue(profile.ClientID),
+		ClientSecret:                 types.StringNull(),
+		ClientSecretWriteOnly:        types.StringNull(),
+		ClientSecretWriteOnlyVersion: types.Int64Null(),
+	}
+	if prior != nil {
+		flattened.ClientSecret = prior.ClientSecret
+		flattened.ClientSecretWriteOnlyVersion = prior.ClientSecretWriteOnlyVersion
+	}
+
+	return flattened
 }
 
-func expandOpenshiftServicePrincipalProfile(input []interface{}) *redhatopenshift.ServicePrincipalProfile {
-	if len(input) == 0 {
+func flattenOpenShiftNetworkProfile(profile *redhatopenshift.NetworkProfile) *openShiftNetworkProfileModel {
+	if profile == nil {
 		return nil
 	}
 
-	config := input[0].(map[string]interface{})
+	return &openShiftNetworkProfileModel{
+		PodCidr:      stringValue(profile.PodCidr),
+		ServiceCidr:  stringValue(profile.ServiceCidr),
+		OutboundType: stringValue(profile.OutboundType),
+	}
+}
+
+func flattenOpenShiftMasterProfile(profile *redhatopenshift.MasterProfile) *openShiftMasterProfileModel {
+	if profile == nil {
+		return nil
+	}
+
+	return &openShiftMasterProfileModel{
+		SubnetId:          stringValue(profile.SubnetID),
+		VmSize:            stringValue(profile.VMSize),
+		EncryptionAtHost:  stringValue(profile.EncryptionAtHost),
+		DiskEncryptionSet: optionalString(stringValue(profile.DiskEncryptionSetID)),
+	}
+}
+
+func flattenOpenShiftWorkerProfiles(profiles []*redhatopenshift.WorkerProfile) *openShiftWorkerProfileModel {
+	// Expect the default worker profile only has one item
+	if len(profiles) != 1 || profiles[0] == nil {
+		return nil
+	}
+	profile := profiles[0]
+
+	return &openShiftWorkerProfileModel{
+		VmSize:            stringValue(profile.VMSize),
+		DiskSizeGb:        int64Value(profile.DiskSizeGB),
+		NodeCount:         int64Value(profile.Count),
+		SubnetId:          stringValue(profile.SubnetID),
+		EncryptionAtHost:  stringValue(profile.EncryptionAtHost),
+		DiskEncryptionSet: optionalString(stringValue(profile.DiskEncryptionSetID)),
+	}
+}
+
+func flattenOpenShiftAPIServerProfile(profile *redhatopenshift.APIServerProfile) *openShiftAPIServerProfileModel {
+	if profile == nil {
+		return nil
+	}
+
+	return &openShiftAPIServerProfileModel{
+		Visibility: stringValue(profile.Visibility),
+		Url:        stringValue(profile.URL),
+		Ip:         stringValue(profile.IP),
+	}
+}
+
+func flattenOpenShiftIngressProfiles(profiles []*redhatopenshift.IngressProfile) *openShiftIngressProfileModel {
+	if len(profiles) == 0 || profiles[0] == nil {
+		return nil
+	}
 
-	clientId := config["client_id"].(string)
-	clientSecret := config["client_secret"].(string)
+	return &openShiftIngressProfileModel{
+		Visibility: stringValue(profiles[0].Visibility),
+		Ip:         stringValue(profiles[0].IP),
+	}
+}
 
+func expandOpenshiftServicePrincipalProfile(clientId, clientSecret string) *redhatopenshift.ServicePrincipalProfile {
 	return &redhatopenshift.ServicePrincipalProfile{
 		ClientID:     utils.String(clientId),
 		ClientSecret: utils.String(clientSecret),
 	}
 }
 
-func expandOpenshiftNetworkProfile(input []interface{}) *redhatopenshift.NetworkProfile {
-	if len(input) == 0 {
+func expandOpenshiftNetworkProfile(input *openShiftNetworkProfileModel) *redhatopenshift.NetworkProfile {
+	if input == nil {
 		return &redhatopenshift.NetworkProfile{
 			PodCidr:      utils.String("10.128.0.0/14"),
 			ServiceCidr:  utils.String("172.30.0.0/16"),
@@ -785,123 +1505,83 @@ func expandOpenshiftNetworkProfile(input []interface{}) *redhatopenshift.Network
 		}
 	}
 
-	config := input[0].(map[string]interface{})
-
-	podCidr := config["pod_cidr"].(string)
-	serviceCidr := config["service_cidr"].(string)
-	outboundType := config["outbound_type"].(string)
-
 	return &redhatopenshift.NetworkProfile{
-		PodCidr:      utils.String(podCidr),
-		ServiceCidr:  utils.String(serviceCidr),
-		OutboundType: to.Ptr(redhatopenshift.OutboundType(outboundType)),
+		PodCidr:      utils.String(input.PodCidr.ValueString()),
+		ServiceCidr:  utils.String(input.ServiceCidr.ValueString()),
+		OutboundType: to.Ptr(redhatopenshift.OutboundType(input.OutboundType.ValueString())),
 	}
 }
 
-func expandOpenshiftMasterProfile(input []interface{}) *redhatopenshift.MasterProfile {
-	if len(input) == 0 {
+func expandOpenshiftMasterProfile(input *openShiftMasterProfileModel) *redhatopenshift.MasterProfile {
+	if input == nil {
 		return nil
 	}
 
-	config := input[0].(map[string]interface{})
-
-	vmSize := config["vm_size"].(string)
-	subnetId := config["subnet_id"].(string)
-	encryptionAtHost := config["encryption_at_host"].(string)
-
-	var diskEncryptionSetId *string
-	if config["disk_encryption_set"] != nil {
-		diskEncryptionSetId = utils.String(config["disk_encryption_set"].(string))
-	}
-
 	return &redhatopenshift.MasterProfile{
-		VMSize:              utils.String(vmSize),
-		SubnetID:            utils.String(subnetId),
-		EncryptionAtHost:    to.Ptr(redhatopenshift.EncryptionAtHost(encryptionAtHost)),
-		DiskEncryptionSetID: diskEncryptionSetId,
+		VMSize:              utils.String(input.VmSize.ValueString()),
+		SubnetID:            utils.String(input.SubnetId.ValueString()),
+		EncryptionAtHost:    to.Ptr(redhatopenshift.EncryptionAtHost(input.EncryptionAtHost.ValueString())),
+		DiskEncryptionSetID: input.DiskEncryptionSet.ValueStringPointer(),
 	}
 }
 
-func expandOpenshiftWorkerProfiles(inputs []interface{}) []*redhatopenshift.WorkerProfile {
-	if len(inputs) == 0 {
+func expandOpenshiftWorkerProfiles(input *openShiftWorkerProfileModel) []*redhatopenshift.WorkerProfile {
+	if input == nil {
 		return nil
 	}
 
-	profiles := make([]*redhatopenshift.WorkerProfile, 0)
-	config := inputs[0].(map[string]interface{})
-
 	// Hardcoded name required by ARO interface
 	workerName := "worker"
 
-	vmSize := config["vm_size"].(string)
+	vmSize := input.VmSize.ValueString()
 	if vmSize == "" {
-		vmSize = "Standard_D4s_v3"
+		vmSize = StandardD4sV3
 	}
 
-	diskSizeGb := int32(config["disk_size_gb"].(int))
+	diskSizeGb := int32(input.DiskSizeGb.ValueInt64())
 	if diskSizeGb == 0 {
 		diskSizeGb = 128
 	}
 
-	nodeCount := int32(config["node_count"].(int))
+	nodeCount := int32(input.NodeCount.ValueInt64())
 	if nodeCount == 0 {
 		nodeCount = 3
 	}
 
-	subnetId := config["subnet_id"].(string)
-	encryptionAtHost := config["encryption_at_host"].(string)
-	var diskEncryptionSetId *string
-	if config["disk_encryption_set"] != nil {
-		diskEncryptionSetId = utils.String(config["disk_encryption_set"].(string))
-	}
-
 	profile := &redhatopenshift.WorkerProfile{
 		Name:                utils.String(workerName),
 		VMSize:              utils.String(vmSize),
 		DiskSizeGB:          utils.Int32(diskSizeGb),
-		SubnetID:            utils.String(subnetId),
+		SubnetID:            utils.String(input.SubnetId.ValueString()),
 		Count:               utils.Int32(nodeCount),
-		EncryptionAtHost:    to.Ptr(redhatopenshift.EncryptionAtHost(encryptionAtHost)),
-		DiskEncryptionSetID: diskEncryptionSetId,
+		EncryptionAtHost:    to.Ptr(redhatopenshift.EncryptionAtHost(input.EncryptionAtHost.ValueString())),
+		DiskEncryptionSetID: input.DiskEncryptionSet.ValueStringPointer(),
 	}
 
-	profiles = append(profiles, profile)
-
-	return profiles
+	return []*redhatopenshift.WorkerProfile{profile}
 }
 
-func expandOpenshiftApiServerProfile(input []interface{}) *redhatopenshift.APIServerProfile {
-	if len(input) == 0 {
-		return &redhatopenshift.APIServerProfile{
-			Visibility: to.Ptr(redhatopenshift.Visibility(APIPublic)),
-		}
+func expandOpenshiftApiServerProfile(input *openShiftAPIServerProfileModel) *redhatopenshift.APIServerProfile {
+	visibility := APIPublic
+	if input != nil {
+		visibility = input.Visibility.ValueString()
 	}
-	config := input[0].(map[string]interface{})
-
-	visibility := config["visibility"].(string)
 
 	return &redhatopenshift.APIServerProfile{
 		Visibility: to.Ptr(redhatopenshift.Visibility(visibility)),
 	}
 }
 
-func expandOpenshiftIngressProfiles(inputs []interface{}) []*redhatopenshift.IngressProfile {
-	profiles := make([]*redhatopenshift.IngressProfile, 0)
-
-	name := utils.String("default")
+func expandOpenshiftIngressProfiles(input *openShiftIngressProfileModel) []*redhatopenshift.IngressProfile {
 	visibility := string(redhatopenshift.VisibilityPublic)
-
-	if len(inputs) > 0 {
-		input := inputs[0].(map[string]interface{})
-		visibility = input["visibility"].(string)
+	if input != nil {
+		visibility = input.Visibility.ValueString()
 	}
 
-	profile := &redhatopenshift.IngressProfile{
-		Name:       name,
-		Visibility: to.Ptr(redhatopenshift.Visibility(visibility)),
+	return []*redhatopenshift.IngressProfile{
+		{
+			Name:       utils.String("default"),
+			Visibility: to.Ptr(redhatopenshift.Visibility(visibility)),
+		},
 	}
-
-	profiles = append(profiles, profile)
-
-	return profiles
 }
@@ -0,0 +1,732 @@
+package azureopenshift
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	redhatopenshift "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/redhatopenshift/armredhatopenshift"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armlocks"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/fake"
+)
+
+func diagnosticsSummary(diags []*tfprotov5.Diagnostic) string {
+	var summaries []string
+	for _, d := range diags {
+		summaries = append(summaries, d.Summary+": "+d.Detail)
+	}
+
+	return strings.Join(summaries, "\n")
+}
+
+func diagnosticsHaveError(diags []*tfprotov5.Diagnostic) bool {
+	for _, d := range diags {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			return true
+		}
+	}
+
+	return false
+}
+
+// testSDKv2State returns the state written by the SDKv2 implementation of the resource.
+func testSDKv2State(t *testing.T, name string) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+// testImportIdentity returns an `import` block of the cluster by its identity, with the attributes set.
+func testImportIdentity(attributes ...string) string {
+	return fmt.Sprintf(`
+import {
+  to = %s
+  identity = {
+    %s
+  }
+}
+`, testClusterResourceName, strings.Join(attributes, "\n    "))
+}
+
+// testCheckOpenShiftClusterNotInState checks that the cluster was removed from state.
+func testCheckOpenShiftClusterNotInState(s *terraform.State) error {
+	if _, ok := s.RootModule().Resources[testClusterResourceName]; ok {
+		return fmt.Errorf("expected %s to be removed from state", testClusterResourceName)
+	}
+
+	return nil
+}
+
+func TestResourceOpenShiftClusterCreate(t *testing.T) {
+	testCases := []struct {
+		name               string
+		attributes         []string
+		existing           bool
+		failure            *fake.Failure
+		lockFailure        *fake.Failure
+		expectErr          *regexp.Regexp
+		expectLockLevel    string
+		expectLock         string
+		expectDomainPrefix string
+		expectNonEmptyPlan bool
+	}{
+		{
+			name: "created",
+		},
+		{
+			name:            "created with a lock",
+			attributes:      []string{`lock_level = "CanNotDelete"`},
+			expectLockLevel: "CanNotDelete",
+			expectLock:      "CanNotDelete",
+		},
+		{
+			// the refused lock is found missing on refresh, and planned again
+			name:               "created without the refused lock",
+			attributes:         []string{`lock_level = "CanNotDelete"`},
+			lockFailure:        &fake.Failure{Operation: fake.OperationCreateLock, StatusCode: 403, Code: "AuthorizationFailed"},
+			expectLockLevel:    "CanNotDelete",
+			expectNonEmptyPlan: true,
+		},
+		{
+			name:               "created with a domain prefix",
+			attributes:         []string{`domain_prefix = "myapp"`},
+			expectDomainPrefix: "myapp-",
+		},
+		{
+			name:      "already exists",
+			existing:  true,
+			expectErr: regexp.MustCompile(`documentation\s+for\s+"azureopenshift_redhatopenshift_cluster"`),
+		},
+		{
+			name:      "refused",
+			failure:   &fake.Failure{Operation: fake.OperationCreateOrUpdate, StatusCode: 400, Code: "InvalidLinkedVNet", Message: "The provided subnet is invalid."},
+			expectErr: regexp.MustCompile("the virtual network is not valid for the cluster"),
+		},
+		{
+			name:      "failed",
+			failure:   &fake.Failure{Operation: fake.OperationCreateOrUpdate, Code: "ResourceQuotaExceeded", Async: true},
+			expectErr: regexp.MustCompile(`waiting\s+for\s+creation`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			testSkipWithoutTerraform(t)
+
+			server := newTestFakeServer(t, fake.Options{})
+			if tc.existing {
+				server.SetCluster(fake.NewCluster(fake.ResourceGroupName, fake.ClusterName))
+			}
+			if tc.failure != nil {
+				server.InjectFailure(*tc.failure)
+			}
+			if tc.lockFailure != nil {
+				server.InjectFailure(*tc.lockFailure)
+			}
+
+			step := resource.TestStep{
+				Config:             server.config(testClusterConfig{attributes: tc.attributes}),
+				ExpectError:        tc.expectErr,
+				ExpectNonEmptyPlan: tc.expectNonEmptyPlan,
+			}
+			if tc.expectErr == nil {
+				step.Check = resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testClusterResourceName, "id", testClusterId),
+					resource.TestCheckResourceAttr(testClusterResourceName, "kubeadmin_password", fake.KubeadminPassword),
+					resource.TestCheckResourceAttr(testClusterResourceName, "lock_level", tc.expectLockLevel),
+					testCheckOpenShiftClusterLock(server, tc.expectLock),
+					testCheckOpenShiftCluster(server, func(cluster redhatopenshift.OpenShiftCluster) error {
+						props := cluster.Properties
+						if secret := *props.ServicePrincipalProfile.ClientSecret; secret != fake.ClusterSecret {
+							return fmt.Errorf("expected the client secret to be sent, got %q", secret)
+						}
+						if rg := *props.ClusterProfile.ResourceGroupID; !strings.Contains(rg, "/resourceGroups/aro-") {
+							return fmt.Errorf("expected a generated cluster resource group, got %q", rg)
+						}
+						if domain := *props.ClusterProfile.Domain; !strings.HasPrefix(domain, tc.expectDomainPrefix) {
+							return fmt.Errorf("expected a domain starting with %q, got %q", tc.expectDomainPrefix, domain)
+						}
+
+						return nil
+					}),
+					testCheckOpenShiftClusterAttr(server, "cluster_profile.0.domain", func(cluster redhatopenshift.OpenShiftCluster) string {
+						return *cluster.Properties.ClusterProfile.Domain
+					}),
+					testCheckOpenShiftClusterAttr(server, "cluster_profile.0.resource_group_id", func(cluster redhatopenshift.OpenShiftCluster) string {
+						return *cluster.Properties.ClusterProfile.ResourceGroupID
+					}),
+				)
+			}
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+				CheckDestroy:             testCheckOpenShiftClusterDestroyed(server),
+				Steps:                    []resource.TestStep{step},
+			})
+		})
+	}
+}
+
+func TestResourceOpenShiftClusterRead(t *testing.T) {
+	testCases := []struct {
+		name               string
+		attributes         []string
+		prepare            func(server *testFakeServer)
+		expectErr          *regexp.Regexp
+		expectRemoved      bool
+		expectLockLevel    string
+		expectNonEmptyPlan bool
+	}{
+		{
+			name: "found",
+		},
+		{
+			name: "gone",
+			prepare: func(server *testFakeServer) {
+				server.RemoveCluster(testClusterId)
+			},
+			expectRemoved:      true,
+			expectNonEmptyPlan: true,
+		},
+		{
+			name:       "credentials refused by a ReadOnly lock",
+			attributes: []string{`lock_level = "ReadOnly"`},
+			prepare: func(server *testFakeServer) {
+				server.InjectFailure(fake.Failure{Operation: fake.OperationListCredentials, StatusCode: 409, Code: "ScopeLocked"})
+			},
+			expectLockLevel: "ReadOnly",
+		},
+		{
+			name: "lock not managed",
+			prepare: func(server *testFakeServer) {
+				server.SetLock(testClusterId, clusterLockName, armlocks.LockLevelCanNotDelete)
+			},
+		},
+		{
+			name:       "lock removed",
+			attributes: []string{`lock_level = "CanNotDelete"`},
+			prepare: func(server *testFakeServer) {
+				server.RemoveLock(testClusterId, clusterLockName)
+			},
+			expectNonEmptyPlan: true,
+		},
+		{
+			name:       "lock refused",
+			attributes: []string{`lock_level = "CanNotDelete"`},
+			prepare: func(server *testFakeServer) {
+				server.InjectFailure(fake.Failure{Operation: fake.OperationGetLock, StatusCode: 403, Code: "AuthorizationFailed"})
+			},
+			expectLockLevel: "CanNotDelete",
+		},
+		{
+			name:       "lock failed",
+			attributes: []string{`lock_level = "CanNotDelete"`},
+			prepare: func(server *testFakeServer) {
+				server.InjectFailure(fake.Failure{Operation: fake.OperationGetLock, StatusCode: 400, Code: "BadRequest"})
+			},
+			expectErr: regexp.MustCompile("management lock"),
+		},
+		{
+			name: "credentials refused",
+			prepare: func(server *testFakeServer) {
+				server.InjectFailure(fake.Failure{Operation: fake.OperationListCredentials, StatusCode: 403, Code: "AuthorizationFailed"})
+			},
+			expectErr: regexp.MustCompile("listing credentials"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			testSkipWithoutTerraform(t)
+
+			server := newTestFakeServer(t, fake.Options{})
+			config := server.config(testClusterConfig{attributes: tc.attributes})
+
+			check := resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr(testClusterResourceName, "id", testClusterId),
+				resource.TestCheckResourceAttr(testClusterResourceName, "name", fake.ClusterName),
+				resource.TestCheckResourceAttr(testClusterResourceName, "tags.env", fake.ClusterEnvironment),
+				resource.TestCheckResourceAttrSet(testClusterResourceName, "console_url"),
+				resource.TestCheckResourceAttr(testClusterResourceName, "lock_level", tc.expectLockLevel),
+				resource.TestCheckResourceAttr(testClusterResourceName, "deletion_protection", "false"),
+			)
+			if tc.expectRemoved {
+				check = testCheckOpenShiftClusterNotInState
+			}
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+				CheckDestroy:             testCheckOpenShiftClusterDestroyed(server),
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+					},
+					{
+						PreConfig: func() {
+							if tc.prepare != nil {
+								tc.prepare(server)
+							}
+						},
+						RefreshState:       true,
+						Check:              check,
+						ExpectError:        tc.expectErr,
+						ExpectNonEmptyPlan: tc.expectNonEmptyPlan,
+					},
+					{
+						// the cluster is refreshed and destroyed once it can be read again
+						PreConfig: server.ClearFailures,
+						Config:    config,
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestResourceOpenShiftClusterImport(t *testing.T) {
+	testCases := []struct {
+		name      string
+		id        string
+		identity  []string
+		expectErr *regexp.Regexp
+	}{
+		{
+			name: "by ID",
+			id:   testClusterId,
+		},
+		{
+			name:     "by identity",
+			identity: []string{fmt.Sprintf("subscription_id = %q", fake.SubscriptionID), fmt.Sprintf("resource_group_name = %q", fake.ResourceGroupName), fmt.Sprintf("name = %q", fake.ClusterName)},
+		},
+		{
+			name:     "by identity in the subscription of the provider",
+			identity: []string{fmt.Sprintf("resource_group_name = %q", fake.ResourceGroupName), fmt.Sprintf("name = %q", fake.ClusterName)},
+		},
+		{
+			name:      "by the ID of a subnet",
+			id:        fake.SubnetID("master"),
+			expectErr: regexp.MustCompile("openShiftClusters"),
+		},
+		{
+			// Terraform may reject the identity before the provider does
+			name:      "by identity without a name",
+			identity:  []string{fmt.Sprintf("resource_group_name = %q", fake.ResourceGroupName)},
+			expectErr: regexp.MustCompile(`parsing\s+Red\s+Hat\s+OpenShift\s+Cluster\s+ID|argument\s+"name"\s+is\s+required`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			testSkipWithoutTerraform(t)
+
+			server := newTestFakeServer(t, fake.Options{})
+			server.SetCluster(fake.NewCluster(fake.ResourceGroupName, fake.ClusterName))
+
+			step := resource.TestStep{
+				ExpectError: tc.expectErr,
+			}
+			if tc.identity != nil {
+				// the import is planned along with the client secret, which the API doesn't return
+				step.Config = server.config(testClusterConfig{extra: testImportIdentity(tc.identity...)})
+				step.PlanOnly = true
+				step.ExpectNonEmptyPlan = true
+			} else {
+				step.Config = server.config(testClusterConfig{})
+				step.ImportState = true
+				step.ImportStateId = tc.id
+				step.ResourceName = testClusterResourceName
+				step.ImportStateCheck = func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected one imported cluster, got %d", len(states))
+					}
+					if id := states[0].ID; id != testClusterId {
+						return fmt.Errorf("expected ID %q, got %q", testClusterId, id)
+					}
+
+					return nil
+				}
+			}
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+				Steps:                    []resource.TestStep{step},
+			})
+		})
+	}
+}
+
+func TestResourceOpenShiftClusterUpdate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		attributes []string
+		failure    *fake.Failure
+		expectErr  *regexp.Regexp
+		expectTag  string
+		expectLock string
+	}{
+		{
+			name:      "tags updated",
+			expectTag: "prod",
+		},
+		{
+			name:       "tags updated under a ReadOnly lock",
+			attributes: []string{`lock_level = "ReadOnly"`},
+			expectTag:  "prod",
+			expectLock: "ReadOnly",
+		},
+		{
+			name:       "update failed under a ReadOnly lock",
+			attributes: []string{`lock_level = "ReadOnly"`},
+			failure:    &fake.Failure{Operation: fake.OperationUpdate, StatusCode: 400, Code: "InvalidParameter", Times: 1},
+			expectErr:  regexp.MustCompile(`updating\s+tags`),
+			expectTag:  "dev",
+			expectLock: "ReadOnly",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			testSkipWithoutTerraform(t)
+
+			server := newTestFakeServer(t, fake.Options{})
+
+			// the update records its own operation
+			var createRequestId string
+			steps := []resource.TestStep{
+				{
+					Config: server.config(testClusterConfig{env: "dev", attributes: tc.attributes}),
+					Check: resource.TestCheckResourceAttrWith(testClusterResourceName, "last_operation.0.request_id", func(value string) error {
+						createRequestId = value
+						return nil
+					}),
+				},
+				{
+					PreConfig: func() {
+						if tc.failure != nil {
+							server.InjectFailure(*tc.failure)
+						}
+					},
+					Config:      server.config(testClusterConfig{env: "prod", attributes: tc.attributes}),
+					ExpectError: tc.expectErr,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrWith(testClusterResourceName, "last_operation.0.request_id", func(value string) error {
+							if value == "" || value == createRequestId {
+								return fmt.Errorf("expected the operation of the update, got %q", value)
+							}
+							return nil
+						}),
+					),
+				},
+			}
+
+			check := resource.ComposeTestCheckFunc(
+				testCheckOpenShiftCluster(server, func(cluster redhatopenshift.OpenShiftCluster) error {
+					if v := *cluster.Tags["env"]; v != tc.expectTag {
+						return fmt.Errorf("expected the `env` tag to be %q, got %q", tc.expectTag, v)
+					}
+					return nil
+				}),
+				testCheckOpenShiftClusterLock(server, tc.expectLock),
+			)
+			if tc.expectErr != nil {
+				// the cluster is left as it was, and its lock restored
+				steps = append(steps, resource.TestStep{
+					Config: server.config(testClusterConfig{env: "dev", attributes: tc.attributes}),
+					Check:  check,
+				})
+			} else {
+				steps[1].Check = resource.ComposeTestCheckFunc(steps[1].Check, check)
+			}
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+				CheckDestroy:             testCheckOpenShiftClusterDestroyed(server),
+				Steps:                    steps,
+			})
+		})
+	}
+}
+
+func TestResourceOpenShiftClusterDelete(t *testing.T) {
+	testCases := []struct {
+		name          string
+		attributes    []string
+		protectionTag string
+		expectErr     *regexp.Regexp
+	}{
+		{
+			name: "deleted",
+		},
+		{
+			name:       "deleted with a lock",
+			attributes: []string{`lock_level = "CanNotDelete"`},
+		},
+		{
+			name:       "protected by deletion_protection",
+			attributes: []string{"deletion_protection = true"},
+			expectErr:  regexp.MustCompile(`deletion\s+protection\s+is\s+enabled`),
+		},
+		{
+			name:          "protected by the deletion protection tag",
+			protectionTag: "env",
+			expectErr:     regexp.MustCompile(`protected\s+by\s+the\s+"env"\s+tag`),
+		},
+		{
+			name:          "not protected by the deletion protection tag",
+			protectionTag: "do-not-delete",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			testSkipWithoutTerraform(t)
+
+			server := newTestFakeServer(t, fake.Options{})
+
+			var providerAttributes []string
+			if tc.protectionTag != "" {
+				providerAttributes = append(providerAttributes, fmt.Sprintf("deletion_protection_tag = %q", tc.protectionTag))
+			}
+			config := server.config(testClusterConfig{attributes: tc.attributes}, providerAttributes...)
+
+			steps := []resource.TestStep{
+				{
+					Config: config,
+					Check:  resource.TestCheckResourceAttr(testClusterResourceName, "id", testClusterId),
+				},
+			}
+			if tc.expectErr != nil {
+				steps = append(steps,
+					resource.TestStep{
+						Config:      config,
+						Destroy:     true,
+						ExpectError: tc.expectErr,
+					},
+					resource.TestStep{
+						// the cluster is kept, and destroyed once unprotected
+						Config: server.config(testClusterConfig{}),
+						Check: testCheckOpenShiftCluster(server, func(redhatopenshift.OpenShiftCluster) error {
+							return nil
+						}),
+					},
+				)
+			}
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+				CheckDestroy:             testCheckOpenShiftClusterDestroyed(server),
+				Steps:                    steps,
+			})
+		})
+	}
+}
+
+func TestResourceOpenShiftClusterWriteOnlySecrets(t *testing.T) {
+	testSkipWithoutTerraform(t)
+
+	// the steps are applied in order, the secrets only being sent again when their version changes
+	steps := []struct {
+		pullSecret          string
+		pullSecretVersion   int
+		clientSecret        string
+		clientSecretVersion int
+		expectPullSecret    string
+		expectClientSecret  string
+	}{
+		{
+			pullSecret:          `{"auths":{}}`,
+			pullSecretVersion:   1,
+			clientSecret:        "first-secret",
+			clientSecretVersion: 1,
+			expectPullSecret:    `{"auths":{}}`,
+			expectClientSecret:  "first-secret",
+		},
+		{
+			// same versions
+			pullSecret:          `{"auths":{}}`,
+			pullSecretVersion:   1,
+			clientSecret:        "ignored-secret",
+			clientSecretVersion: 1,
+			expectPullSecret:    `{"auths":{}}`,
+			expectClientSecret:  "first-secret",
+		},
+		{
+			// client secret rotated
+			pullSecret:          `{"auths":{}}`,
+			pullSecretVersion:   1,
+			clientSecret:        "second-secret",
+			clientSecretVersion: 2,
+			expectPullSecret:    `{"auths":{}}`,
+			expectClientSecret:  "second-secret",
+		},
+		{
+			// pull secret rotated
+			pullSecret:          `{"auths":{"registry":{}}}`,
+			pullSecretVersion:   2,
+			clientSecret:        "ignored-secret",
+			clientSecretVersion: 2,
+			expectPullSecret:    `{"auths":{"registry":{}}}`,
+			expectClientSecret:  "second-secret",
+		},
+	}
+
+	server := newTestFakeServer(t, fake.Options{})
+
+	var testSteps []resource.TestStep
+	for _, step := range steps {
+		step := step
+		testSteps = append(testSteps, resource.TestStep{
+			Config: server.config(testClusterConfig{
+				clusterProfile: []string{
+					fmt.Sprintf("pull_secret_wo         = %q", step.pullSecret),
+					fmt.Sprintf("pull_secret_wo_version = %d", step.pullSecretVersion),
+				},
+				servicePrincipal: []string{
+					fmt.Sprintf("client_secret_wo         = %q", step.clientSecret),
+					fmt.Sprintf("client_secret_wo_version = %d", step.clientSecretVersion),
+				},
+			}),
+			Check: resource.ComposeTestCheckFunc(
+				testCheckOpenShiftCluster(server, func(cluster redhatopenshift.OpenShiftCluster) error {
+					if v := *cluster.Properties.ClusterProfile.PullSecret; v != step.expectPullSecret {
+						return fmt.Errorf("expected pull secret %q, got %q", step.expectPullSecret, v)
+					}
+					if v := *cluster.Properties.ServicePrincipalProfile.ClientSecret; v != step.expectClientSecret {
+						return fmt.Errorf("expected client secret %q, got %q", step.expectClientSecret, v)
+					}
+					if v := *cluster.Properties.ClusterProfile.Domain; v == "" {
+						return fmt.Errorf("expected the rest of the cluster profile to be kept")
+					}
+					return nil
+				}),
+				func(s *terraform.State) error {
+					for k, v := range s.RootModule().Resources[testClusterResourceName].Primary.Attributes {
+						if strings.Contains(v, step.clientSecret) || strings.Contains(v, "auths") {
+							return fmt.Errorf("expected the secrets to be kept out of state, got %s = %q", k, v)
+						}
+					}
+					return nil
+				},
+				resource.TestCheckResourceAttr(testClusterResourceName, "cluster_profile.0.pull_secret_wo_version", strconv.Itoa(step.pullSecretVersion)),
+				resource.TestCheckResourceAttr(testClusterResourceName, "service_principal.0.client_secret_wo_version", strconv.Itoa(step.clientSecretVersion)),
+			),
+		})
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testCheckOpenShiftClusterDestroyed(server),
+		Steps:                    testSteps,
+	})
+}
+
+func TestResourceOpenShiftClusterInvalidClientSecret(t *testing.T) {
+	testCases := []struct {
+		name             string
+		servicePrincipal []string
+		expectErr        *regexp.Regexp
+	}{
+		{
+			name:             "client secret",
+			servicePrincipal: []string{fmt.Sprintf("client_secret = %q", fake.ClusterSecret)},
+			expectErr:        regexp.MustCompile(`(?s)the service principal credentials are invalid.*\d+:\s+client_secret\s+=`),
+		},
+		{
+			name:             "write-only client secret",
+			servicePrincipal: []string{fmt.Sprintf("client_secret_wo = %q", fake.ClusterSecret)},
+			expectErr:        regexp.MustCompile(`(?s)the service principal credentials are invalid.*\d+:\s+client_secret_wo\s+=`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			testSkipWithoutTerraform(t)
+
+			server := newTestFakeServer(t, fake.Options{})
+			server.InjectFailure(fake.Failure{Operation: fake.OperationCreateOrUpdate, StatusCode: 400, Code: "InvalidServicePrincipalCredentials"})
+
+			// the error points at the attribute holding the secret
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:      server.config(testClusterConfig{servicePrincipal: tc.servicePrincipal}),
+						ExpectError: tc.expectErr,
+					},
+				},
+			})
+		})
+	}
+}
+
+// TestResourceOpenShiftClusterSDKv2State checks that the state written by the SDKv2 implementation of the resource is
+// upgraded with the values SDKv2 computed, including those of the blocks it computed.
+func TestResourceOpenShiftClusterSDKv2State(t *testing.T) {
+	ctx := context.Background()
+
+	server, err := testAccProtoV5ProviderFactories["azureopenshift"]()
+	if err != nil {
+		t.Fatalf("creating the provider server: %+v", err)
+	}
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("retrieving the schemas: %+v", err)
+	}
+	schema := schemaResp.ResourceSchemas["azureopenshift_redhatopenshift_cluster"]
+
+	resp, err := server.UpgradeResourceState(ctx, &tfprotov5.UpgradeResourceStateRequest{
+		TypeName: "azureopenshift_redhatopenshift_cluster",
+		Version:  0,
+		RawState: &tfprotov5.RawState{JSON: testSDKv2State(t, "redhatopenshift_cluster_sdkv2_state.json")},
+	})
+	if err != nil {
+		t.Fatalf("upgrading the state: %+v", err)
+	}
+	if diagnosticsHaveError(resp.Diagnostics) {
+		t.Fatalf("upgrading the state: %s", diagnosticsSummary(resp.Diagnostics))
+	}
+	state, err := resp.UpgradedState.Unmarshal(schema.ValueType())
+	if err != nil {
+		t.Fatalf("decoding the state: %+v", err)
+	}
+
+	block := func(name string, attribute string) *tftypes.AttributePath {
+		return tftypes.NewAttributePath().WithAttributeName(name).WithElementKeyInt(0).WithAttributeName(attribute)
+	}
+	for _, tc := range []struct {
+		path     *tftypes.AttributePath
+		expected string
+	}{
+		{path: tftypes.NewAttributePath().WithAttributeName("id"), expected: testClusterId},
+		{path: tftypes.NewAttributePath().WithAttributeName("tags").WithElementKeyString("env"), expected: "dev"},
+		{path: block("cluster_profile", "domain"), expected: "if6nm9kg"},
+		{path: block("service_principal", "client_secret"), expected: fake.ClusterSecret},
+		{path: block("worker_profile", "vm_size"), expected: "Standard_D4s_v3"},
+		{path: block("network_profile", "pod_cidr"), expected: "10.128.0.0/14"},
+		{path: block("api_server_profile", "url"), expected: "https://api.if6nm9kg.eastus.aroapp.io:6443/"},
+		{path: block("ingress_profile", "ip"), expected: "20.0.0.11"},
+	} {
+		found, _, err := tftypes.WalkAttributePath(state, tc.path)
+		if err != nil {
+			t.Fatalf("expected %s to be upgraded: %+v", tc.path, err)
+		}
+		var v string
+		if err := found.(tftypes.Value).As(&v); err != nil || v != tc.expected {
+			t.Fatalf("expected %s to be %q, got %q", tc.path, tc.expected, v)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package azureopenshift
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	redhatopenshift "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/redhatopenshift/armredhatopenshift"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/clients"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift/fake"
+)
+
+var testAccProtoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+	"azureopenshift": func() (tfprotov5.ProviderServer, error) {
+		serverFactory, err := ProtoV5ProviderServerFactory(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		return serverFactory(), nil
+	},
+}
+
+const testClusterResourceName = "azureopenshift_redhatopenshift_cluster.test"
+
+var (
+	testClusterId           = fake.ClusterID(fake.ResourceGroupName, fake.ClusterName)
+	testClusterIdentityType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"subscription_id":     tftypes.String,
+		"resource_group_name": tftypes.String,
+		"name":                tftypes.String,
+	}}
+)
+
+// testClient returns a client backed by in-memory fakes.
+func testClient(clusters *fake.ClustersClient, locks *fake.ManagementLocksClient) *clients.Client {
+	return &clients.Client{
+		OpenShiftClustersClient:    clusters,
+		OpenShiftCredentialsClient: clusters,
+		ManagementLocksClient:      locks,
+		SubscriptionID:             fake.SubscriptionID,
+		StopCtx:                    context.Background(),
+		RetryOptions:               clients.RetryOptions{Attempts: 1},
+	}
+}
+
+// testFakeServer is the fake ARM server the provider is pointed at by the tests driving it through Terraform.
+type testFakeServer struct {
+	*fake.Server
+	caBundlePath string
+}
+
+// newTestFakeServer starts a fake ARM server, which is closed once the test is done.
+func newTestFakeServer(t *testing.T, options fake.Options) *testFakeServer {
+	t.Helper()
+
+	server := fake.NewServer(options)
+	t.Cleanup(server.Close)
+
+	caBundlePath := filepath.Join(t.TempDir(), "ca.pem")
+	if err := server.WriteCABundle(caBundlePath); err != nil {
+		t.Fatalf("writing CA bundle: %+v", err)
+	}
+
+	return &testFakeServer{Server: server, caBundlePath: caBundlePath}
+}
+
+// config returns the configuration of the cluster with the provider pointed at the fake, the provider attributes being
+// added to its block.
+func (s *testFakeServer) config(cluster testClusterConfig, providerAttributes ...string) string {
+	return s.ProviderConfig(s.caBundlePath, providerAttributes...) + cluster.String()
+}
+
+// testSkipWithoutTerraform skips the tests driving the provider through Terraform, which resource.UnitTest runs
+// regardless of TF_ACC, when there is no Terraform binary to run them with.
+func testSkipWithoutTerraform(t *testing.T) {
+	t.Helper()
+
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") != "" || os.Getenv("TF_ACC_TERRAFORM_VERSION") != "" {
+		return
+	}
+	if _, err := exec.LookPath("terraform"); err != nil {
+		t.Skip("the terraform binary was not found")
+	}
+}
+
+// testClusterConfig is the configuration of the `azureopenshift_redhatopenshift_cluster.test` cluster, which is the
+// fake's fixture when left empty.
+type testClusterConfig struct {
+	// env is the value of the `env` tag, fake.ClusterEnvironment when empty
+	env string
+
+	// attributes are added to the cluster, one per line, such as `lock_level = "CanNotDelete"`
+	attributes []string
+
+	// clusterProfile is the content of the `cluster_profile` block, which is declared even when empty so that its
+	// computed values are exported
+	clusterProfile []string
+
+	// servicePrincipal is the content of the `service_principal` block, the fake's client secret when empty
+	servicePrincipal []string
+
+	// extra is appended to the configuration, such as an `import` block
+	extra string
+}
+
+func (c testClusterConfig) String() string {
+	env := c.env
+	if env == "" {
+		env = fake.ClusterEnvironment
+	}
+
+	servicePrincipal := c.servicePrincipal
+	if len(servicePrincipal) == 0 {
+		servicePrincipal = []string{fmt.Sprintf("client_secret = %q", fake.ClusterSecret)}
+	}
+
+	return fmt.Sprintf(`
+resource "azureopenshift_redhatopenshift_cluster" "test" {
+  name                = %q
+  location            = "eastus"
+  resource_group_name = %q
+  %s
+
+  master_profile {
+    subnet_id = %q
+  }
+
+  worker_profile {
+    subnet_id = %q
+  }
+
+  cluster_profile {
+    %s
+  }
+
+  service_principal {
+    client_id = %q
+    %s
+  }
+
+  tags = {
+    env = %q
+  }
+}
+%s`, fake.ClusterName, fake.ResourceGroupName, strings.Join(c.attributes, "\n  "), fake.SubnetID("master"), fake.SubnetID("worker"),
+		strings.Join(c.clusterProfile, "\n    "), fake.ClientID, strings.Join(servicePrincipal, "\n    "), env, c.extra)
+}
+
+// testCheckOpenShiftCluster checks the cluster as the fake stores it, including the secrets it was sent.
+func testCheckOpenShiftCluster(server *testFakeServer, check func(cluster redhatopenshift.OpenShiftCluster) error) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		cluster, ok := server.StoredCluster(testClusterId)
+		if !ok {
+			return fmt.Errorf("Red Hat OpenShift Cluster %q does not exist", testClusterId)
+		}
+
+		return check(cluster)
+	}
+}
+
+// testCheckOpenShiftClusterAttr checks that the attribute of the cluster in state has the value the fake stores.
+func testCheckOpenShiftClusterAttr(server *testFakeServer, key string, value func(cluster redhatopenshift.OpenShiftCluster) string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		cluster, ok := server.Cluster(testClusterId)
+		if !ok {
+			return fmt.Errorf("Red Hat OpenShift Cluster %q does not exist", testClusterId)
+		}
+
+		return resource.TestCheckResourceAttr(testClusterResourceName, key, value(cluster))(s)
+	}
+}
+
+// testCheckOpenShiftClusterLock checks the level of the management lock of the cluster, which is empty without one.
+func testCheckOpenShiftClusterLock(server *testFakeServer, level string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		found := ""
+		if lock, ok := server.Lock(testClusterId, clusterLockName); ok {
+			found = string(*lock.Properties.Level)
+		}
+		if found != level {
+			return fmt.Errorf("expected lock level %q, got %q", level, found)
+		}
+
+		return nil
+	}
+}
+
+func TestAccOpenShiftCluster_fake(t *testing.T) {
+	server := newTestFakeServer(t, fake.Options{})
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testCheckOpenShiftClusterDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(testClusterConfig{env: "dev"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("azureopenshift_redhatopenshift_cluster.test", "kubeadmin_username", fake.KubeadminUsername),
+					resource.TestCheckResourceAttr("azureopenshift_redhatopenshift_cluster.test", "tags.env", "dev"),
+					resource.TestCheckResourceAttrSet("azureopenshift_redhatopenshift_cluster.test", "console_url"),
+					resource.TestCheckResourceAttrSet("azureopenshift_redhatopenshift_cluster.test", "cluster_profile.0.domain"),
+				),
+			},
+			{
+				Config: server.config(testClusterConfig{env: "prod"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("azureopenshift_redhatopenshift_cluster.test", "tags.env", "prod"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOpenShiftCluster_fakeFailedCreate(t *testing.T) {
+	server := newTestFakeServer(t, fake.Options{})
+	server.InjectFailure(fake.Failure{
+		Operation:  fake.OperationCreateOrUpdate,
+		StatusCode: 400,
+		Code:       "InvalidLinkedVNet",
+		Message:    "The provided subnet is invalid.",
+	})
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      server.config(testClusterConfig{env: "dev"}),
+				ExpectError: regexp.MustCompile("the virtual network is not valid for the cluster"),
+			},
+		},
+	})
+}
+
+func testCheckOpenShiftClusterDestroyed(server *testFakeServer) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if !strings.HasPrefix(rs.Type, "azureopenshift_redhat") {
+				continue
+			}
+
+			if _, ok := server.Cluster(rs.Primary.ID); ok {
+				return fmt.Errorf("Red Hat OpenShift Cluster %q still exists", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
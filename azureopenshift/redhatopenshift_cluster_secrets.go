@@ -0,0 +1,164 @@
+package azureopenshift
+
+import (
+	"context"
+	"fmt"
+
+	redhatopenshift "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/redhatopenshift/armredhatopenshift"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/utils"
+)
+
+// The paths of the write-only variants of the secrets.  Terraform only ever sends them in the configuration: they are
+// never planned nor kept in state, so changing them is signalled by their `_version` attribute.
+var (
+	pullSecretWriteOnlyPath   = path.Root("cluster_profile").AtListIndex(0).AtName("pull_secret_wo")
+	clientSecretWriteOnlyPath = path.Root("service_principal").AtListIndex(0).AtName("client_secret_wo")
+)
+
+// preferWriteOnlySecrets warns about secrets which are kept in state when Terraform supports their write-only variants.
+func preferWriteOnlySecrets(ctx context.Context, config openShiftClusterModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	warn := func(p, writeOnly path.Path) {
+		diags.AddAttributeWarning(p, "Available Write-only Attribute Alternative",
+			fmt.Sprintf("The attribute %s has a write-only alternative %s available. Use the write-only alternative of the attribute when possible.", p, writeOnly))
+	}
+
+	if profile := firstBlock[openShiftClusterProfileModel](ctx, config.ClusterProfile, &diags); profile != nil && !profile.PullSecret.IsNull() {
+		warn(path.Root("cluster_profile").AtListIndex(0).AtName("pull_secret"), pullSecretWriteOnlyPath)
+	}
+	if sp := firstBlock[openShiftServicePrincipalModel](ctx, config.ServicePrincipal, &diags); sp != nil && !sp.ClientSecret.IsNull() {
+		warn(path.Root("service_principal").AtListIndex(0).AtName("client_secret"), clientSecretWriteOnlyPath)
+	}
+
+	return diags
+}
+
+// openShiftClusterPullSecret returns the pull secret from `cluster_profile.0.pull_secret_wo`, or
+// `cluster_profile.0.pull_secret`.
+func openShiftClusterPullSecret(ctx context.Context, config tfsdk.Config, plan openShiftClusterModel) (string, diag.Diagnostics) {
+	pullSecret, diags := writeOnlyString(ctx, config, pullSecretWriteOnlyPath)
+	if diags.HasError() || pullSecret != "" {
+		return pullSecret, diags
+	}
+
+	if profile := firstBlock[openShiftClusterProfileModel](ctx, plan.ClusterProfile, &diags); profile != nil {
+		pullSecret = profile.PullSecret.ValueString()
+	}
+
+	return pullSecret, diags
+}
+
+// openShiftClusterClientSecret returns the client secret from `service_principal.0.client_secret_wo`, or
+// `service_principal.0.client_secret`.
+func openShiftClusterClientSecret(ctx context.Context, config tfsdk.Config, plan openShiftClusterModel) (string, diag.Diagnostics) {
+	clientSecret, diags := writeOnlyString(ctx, config, clientSecretWriteOnlyPath)
+	if diags.HasError() || clientSecret != "" {
+		return clientSecret, diags
+	}
+
+	if sp := firstBlock[openShiftServicePrincipalModel](ctx, plan.ServicePrincipal, &diags); sp != nil {
+		clientSecret = sp.ClientSecret.ValueString()
+	}
+
+	return clientSecret, diags
+}
+
+// expandOpenShiftClusterSecretsUpdate returns the properties updating the secrets of the cluster which changed, or nil
+// when none did.
+func expandOpenShiftClusterSecretsUpdate(ctx context.Context, config tfsdk.Config, plan, state openShiftClusterModel) (*redhatopenshift.OpenShiftClusterProperties, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var properties *redhatopenshift.OpenShiftClusterProperties
+
+	if pullSecretChanged(ctx, plan, state, &diags) {
+		pullSecret, d := openShiftClusterPullSecret(ctx, config, plan)
+		diags.Append(d...)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// a pull secret can't be removed from a cluster
+		if pullSecret != "" {
+			properties = &redhatopenshift.OpenShiftClusterProperties{
+				ClusterProfile: &redhatopenshift.ClusterProfile{
+					PullSecret: utils.String(pullSecret),
+				},
+			}
+		}
+	}
+
+	if clientSecretChanged(ctx, plan, state, &diags) {
+		planSp := firstBlock[openShiftServicePrincipalModel](ctx, plan.ServicePrincipal, &diags)
+		clientSecret, d := openShiftClusterClientSecret(ctx, config, plan)
+		diags.Append(d...)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		if properties == nil {
+			properties = &redhatopenshift.OpenShiftClusterProperties{}
+		}
+		properties.ServicePrincipalProfile = &redhatopenshift.ServicePrincipalProfile{
+			ClientID:     utils.String(planSp.ClientId.ValueString()),
+			ClientSecret: utils.String(clientSecret),
+		}
+	}
+
+	return properties, diags
+}
+
+// pullSecretChanged returns whether the update sends the pull secret to the cluster again.
+func pullSecretChanged(ctx context.Context, plan, state openShiftClusterModel, diags *diag.Diagnostics) bool {
+	planProfile := firstBlock[openShiftClusterProfileModel](ctx, plan.ClusterProfile, diags)
+	stateProfile := firstBlock[openShiftClusterProfileModel](ctx, state.ClusterProfile, diags)
+
+	return !planProfile.pullSecret().Equal(stateProfile.pullSecret()) || !planProfile.pullSecretWriteOnlyVersion().Equal(stateProfile.pullSecretWriteOnlyVersion())
+}
+
+// clientSecretChanged returns whether the update sends the client secret to the cluster again.
+func clientSecretChanged(ctx context.Context, plan, state openShiftClusterModel, diags *diag.Diagnostics) bool {
+	planSp := firstBlock[openShiftServicePrincipalModel](ctx, plan.ServicePrincipal, diags)
+	stateSp := firstBlock[openShiftServicePrincipalModel](ctx, state.ServicePrincipal, diags)
+
+	return planSp != nil && (stateSp == nil || !planSp.ClientSecret.Equal(stateSp.ClientSecret) || !planSp.ClientSecretWriteOnlyVersion.Equal(stateSp.ClientSecretWriteOnlyVersion))
+}
+
+// pullSecret returns the pull secret of the profile, which is null when there's no profile.
+func (m *openShiftClusterProfileModel) pullSecret() types.String {
+	if m == nil {
+		return types.StringNull()
+	}
+
+	return m.PullSecret
+}
+
+// pullSecretWriteOnlyVersion returns the version of the write-only pull secret of the profile, which is null when
+// there's no profile.
+func (m *openShiftClusterProfileModel) pullSecretWriteOnlyVersion() types.Int64 {
+	if m == nil {
+		return types.Int64Null()
+	}
+
+	return m.PullSecretWriteOnlyVersion
+}
+
+// writeOnlyString returns the value of the write-only attribute at the path, or an empty string when it isn't set.
+func writeOnlyString(ctx context.Context, config tfsdk.Config, p path.Path) (string, diag.Diagnostics) {
+	// the configuration isn't sent when the cluster is read or deleted
+	if config.Raw.IsNull() {
+		return "", nil
+	}
+
+	var v types.String
+	diags := config.GetAttribute(ctx, p, &v)
+	if diags.HasError() {
+		return "", diags
+	}
+
+	return v.ValueString(), nil
+}
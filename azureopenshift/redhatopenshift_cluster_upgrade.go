@@ -0,0 +1,71 @@
+package azureopenshift
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithUpgradeState = &openShiftClusterResource{}
+
+// UpgradeState upgrades the states written by SDKv2, which have the same schema, but empty values rather than null
+// for the attributes which aren't configured.
+func (r *openShiftClusterResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schemaResp.Schema,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var state openShiftClusterModel
+				resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(state.nullSDKv2EmptyValues(ctx)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			},
+		},
+	}
+}
+
+// nullSDKv2EmptyValues nulls the empty values SDKv2 stored for the optional attributes which aren't configured, which
+// the framework plans as null.
+func (m *openShiftClusterModel) nullSDKv2EmptyValues(ctx context.Context) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.ClusterResourceGroup = optionalString(m.ClusterResourceGroup)
+	m.LockLevel = optionalString(m.LockLevel)
+	for _, tags := range []*types.Map{&m.Tags, &m.ManagedResourceGroupTags} {
+		if len(tags.Elements()) == 0 {
+			*tags = types.MapNull(types.StringType)
+		}
+	}
+
+	if profile := firstBlock[openShiftClusterProfileModel](ctx, m.ClusterProfile, &diags); profile != nil {
+		profile.PullSecret = optionalString(profile.PullSecret)
+		m.ClusterProfile = blockValue(ctx, m.ClusterProfile.ElementType(ctx), profile, &diags)
+	}
+	if sp := firstBlock[openShiftServicePrincipalModel](ctx, m.ServicePrincipal, &diags); sp != nil {
+		sp.ClientSecret = optionalString(sp.ClientSecret)
+		m.ServicePrincipal = blockValue(ctx, m.ServicePrincipal.ElementType(ctx), sp, &diags)
+	}
+	if profile := firstBlock[openShiftMasterProfileModel](ctx, m.MasterProfile, &diags); profile != nil {
+		profile.DiskEncryptionSet = optionalString(profile.DiskEncryptionSet)
+		m.MasterProfile = blockValue(ctx, m.MasterProfile.ElementType(ctx), profile, &diags)
+	}
+	if profile := firstBlock[openShiftWorkerProfileModel](ctx, m.WorkerProfile, &diags); profile != nil {
+		profile.DiskEncryptionSet = optionalString(profile.DiskEncryptionSet)
+		m.WorkerProfile = blockValue(ctx, m.WorkerProfile.ElementType(ctx), profile, &diags)
+	}
+
+	return diags
+}
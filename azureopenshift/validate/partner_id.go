@@ -0,0 +1,26 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-uuid"
+)
+
+// PartnerID validates the Partner ID is a GUID, optionally prefixed with `pid-`
+func PartnerID(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+	}
+
+	if v == "" {
+		return nil, nil
+	}
+
+	if _, err := uuid.ParseUUID(strings.TrimPrefix(v, "pid-")); err != nil {
+		return nil, []error{fmt.Errorf("expected %q to be a GUID, optionally prefixed with `pid-`, got %q", k, v)}
+	}
+
+	return nil, nil
+}
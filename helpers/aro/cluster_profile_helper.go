@@ -2,8 +2,6 @@ package aro
 
 import (
 	"fmt"
-	"math/rand"
-	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	redhatopenshift "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/redhatopenshift/armredhatopenshift"
@@ -13,29 +11,27 @@ import (
 type ClusterProfileHelper struct {
 	subscriptionId       string
 	clusterResourceGroup string
+	seed                 string
+	domainPrefix         string
 }
 
-func NewClusterProfileHelper(subscriptionId, clusterResourceGroup string) *ClusterProfileHelper {
+// NewClusterProfileHelper returns a helper expanding the cluster profile.  The seed is used to derive the domain and
+// the cluster resource group when they aren't set, so the same values are returned on every call.  The derived domain
+// starts with the domain prefix when it isn't empty.
+func NewClusterProfileHelper(subscriptionId, clusterResourceGroup, seed, domainPrefix string) *ClusterProfileHelper {
 	return &ClusterProfileHelper{
 		subscriptionId:       subscriptionId,
 		clusterResourceGroup: clusterResourceGroup,
+		seed:                 seed,
+		domainPrefix:         domainPrefix,
 	}
 }
 
 func (cpe *ClusterProfileHelper) Expand(input []interface{}) *redhatopenshift.ClusterProfile {
-	randomDomainName := generateRandomDomainName()
-	resourceGroupName := fmt.Sprintf("aro-%s", randomDomainName)
-	var resourceGroupId string = ""
-	if cpe.clusterResourceGroup == "" {
-		resourceGroupId = resourceGroupID(cpe.subscriptionId, resourceGroupName)
-	} else {
-		resourceGroupId = resourceGroupID(cpe.subscriptionId, cpe.clusterResourceGroup)
-	}
-
-	if len(input) == 0 {
+	if len(input) == 0 || input[0] == nil {
 		return &redhatopenshift.ClusterProfile{
-			ResourceGroupID:      utils.String(resourceGroupId),
-			Domain:               utils.String(randomDomainName),
+			ResourceGroupID:      utils.String(cpe.ResourceGroupID("")),
+			Domain:               utils.String(cpe.Domain("")),
 			FipsValidatedModules: to.Ptr(redhatopenshift.FipsValidatedModulesDisabled),
 		}
 	}
@@ -48,13 +44,12 @@ func (cpe *ClusterProfileHelper) Expand(input []interface{}) *redhatopenshift.Cl
 
 	fipsValidatedModules := config["fips_validated_modules"].(string)
 
-	domain := config["domain"].(string)
-	if domain == "" {
-		domain = randomDomainName
-	}
+	domain := cpe.Domain(config["domain"].(string))
+
+	resourceGroupId, _ := config["resource_group_id"].(string)
 
 	return &redhatopenshift.ClusterProfile{
-		ResourceGroupID:      utils.String(resourceGroupId),
+		ResourceGroupID:      utils.String(cpe.ResourceGroupID(resourceGroupId)),
 		Domain:               utils.String(domain),
 		PullSecret:           utils.String(pullSecret),
 		Version:              utils.String(version),
@@ -62,27 +57,30 @@ func (cpe *ClusterProfileHelper) Expand(input []interface{}) *redhatopenshift.Cl
 	}
 }
 
-func generateRandomDomainName() string {
-	randomPrefix := randomString("abcdefghijklmnopqrstuvwxyz", 1)
-	randomName := randomString("abcdefghijklmnopqrstuvwxyz1234567890", 7)
+// Domain returns the domain of the cluster: the existing one, or else one derived from the seed and the domain prefix.
+func (cpe *ClusterProfileHelper) Domain(existing string) string {
+	if existing != "" {
+		return existing
+	}
 
-	return fmt.Sprintf("%s%s", randomPrefix, randomName)
+	return DomainName(cpe.seed, cpe.domainPrefix)
 }
 
-func resourceGroupID(subscriptionId string, resourceGroupName string) string {
-	fmtString := "/subscriptions/%s/resourceGroups/%s"
-	return fmt.Sprintf(fmtString, subscriptionId, resourceGroupName)
-}
-
-func randomString(acceptedChars string, size int) string {
-	charSet := []rune(acceptedChars)
-	randomChars := make([]rune, size)
-
-	rand.Seed(time.Now().UnixNano())
+// ResourceGroupID returns the ID of the resource group ARO creates for the cluster's resources: the configured
+// cluster resource group, then the existing ID, and finally a name derived from the seed.
+func (cpe *ClusterProfileHelper) ResourceGroupID(existing string) string {
+	if cpe.clusterResourceGroup != "" {
+		return resourceGroupID(cpe.subscriptionId, cpe.clusterResourceGroup)
+	}
 
-	for i := range randomChars {
-		randomChars[i] = charSet[rand.Intn(len(charSet))]
+	if existing != "" {
+		return existing
 	}
 
-	return string(randomChars)
+	return resourceGroupID(cpe.subscriptionId, ResourceGroupName(cpe.seed))
+}
+
+func resourceGroupID(subscriptionId string, resourceGroupName string) string {
+	fmtString := "/subscriptions/%s/resourceGroups/%s"
+	return fmt.Sprintf(fmtString, subscriptionId, resourceGroupName)
 }
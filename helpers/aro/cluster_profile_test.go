@@ -16,6 +16,7 @@ var _ = Describe("Cluster Test", func() {
 	var subscription_id string
 	var expectedPullSecret, domain string
 	var cluster_resource_group string
+	var seed string
 
 	BeforeEach(func() {
 		expectedPullSecret = "this is my pull secret"
@@ -31,7 +32,8 @@ var _ = Describe("Cluster Test", func() {
 		}
 		subscription_id = "123456"
 		cluster_resource_group = ""
-		cph = aro.NewClusterProfileHelper(subscription_id, cluster_resource_group)
+		seed = "0123456789abcdef0123456789abcdef"
+		cph = aro.NewClusterProfileHelper(subscription_id, cluster_resource_group, seed, "")
 	})
 
 	Context("When both pull secret and domain is provided", func() {
@@ -44,25 +46,55 @@ var _ = Describe("Cluster Test", func() {
 
 	Context("When domain is not provided", func() {
 		BeforeEach(func() {
-			domain = ""
+			input[0].(map[string]interface{})["domain"] = ""
 		})
-		It("Should return cluster profile with a random domain", func() {
+		It("Should return cluster profile with a domain derived from the seed", func() {
 			cp := cph.Expand(input)
-			Ω(*cp.Domain).Should(Not(Equal("")))
+			Ω(*cp.Domain).Should(Equal(aro.DomainName(seed, "")))
+		})
+		It("Should return the same domain on every call", func() {
+			Ω(*cph.Expand(input).Domain).Should(Equal(*cph.Expand(input).Domain))
+		})
+	})
+
+	Context("When domain is not provided but a domain prefix is", func() {
+		BeforeEach(func() {
+			input[0].(map[string]interface{})["domain"] = ""
+			cph = aro.NewClusterProfileHelper(subscription_id, cluster_resource_group, seed, "myapp")
+		})
+		It("Should return cluster profile with a domain starting with the prefix", func() {
+			cp := cph.Expand(input)
+			Ω(*cp.Domain).Should(Equal(aro.DomainName(seed, "myapp")))
+			Ω(strings.HasPrefix(*cp.Domain, "myapp-")).Should(Equal(true))
+		})
+		It("Should use the prefix without a cluster profile", func() {
+			cp := cph.Expand(nil)
+			Ω(*cp.Domain).Should(Equal(aro.DomainName(seed, "myapp")))
 		})
 	})
 
 	Context("When cluster resource group is not provided", func() {
-		It("Should return resource group id with aro prefix random name", func() {
+		It("Should return resource group id with aro prefix name derived from the seed", func() {
+			cp := cph.Expand(input)
+			Ω(strings.HasPrefix(*cp.ResourceGroupID, "/subscriptions/123456/resourceGroups/aro-")).Should(Equal(true))
+			Ω(*cp.ResourceGroupID).Should(Equal(*cph.Expand(input).ResourceGroupID))
+		})
+	})
+
+	Context("When the resource group id is already known", func() {
+		BeforeEach(func() {
+			input[0].(map[string]interface{})["resource_group_id"] = "/subscriptions/123456/resourceGroups/aro-existing"
+		})
+		It("Should return the existing resource group id", func() {
 			cp := cph.Expand(input)
-			Ω(strings.Contains(*cp.ResourceGroupID, "aro-")).Should(Equal(true))
+			Ω(*cp.ResourceGroupID).Should(Equal("/subscriptions/123456/resourceGroups/aro-existing"))
 		})
 	})
 
 	Context("When cluster resource group is provided", func() {
 		BeforeEach(func() {
 			cluster_resource_group = "custom_resource_group"
-			cph = aro.NewClusterProfileHelper(subscription_id, cluster_resource_group)
+			cph = aro.NewClusterProfileHelper(subscription_id, cluster_resource_group, seed, "")
 		})
 		It("Should return resource group id with resource group name", func() {
 			cp := cph.Expand(input)
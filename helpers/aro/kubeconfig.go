@@ -0,0 +1,111 @@
+package aro
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Kubeconfig holds the connection details of a kubeconfig, in the shape of the arguments of the kubernetes and helm
+// providers: the certificates and key are PEM encoded.
+type Kubeconfig struct {
+	Host                 string
+	ClusterCACertificate string
+	Insecure             bool
+	ClientCertificate    string
+	ClientKey            string
+	Token                string
+}
+
+type kubeconfigFile struct {
+	CurrentContext string `yaml:"current-context"`
+	Clusters       []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+			InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			ClientCertificateData string `yaml:"client-certificate-data"`
+			ClientKeyData         string `yaml:"client-key-data"`
+			Token                 string `yaml:"token"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+	Contexts []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster string `yaml:"cluster"`
+			User    string `yaml:"user"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+}
+
+// ParseKubeconfig returns the connection details of the current context of the kubeconfig, or of its first cluster
+// and user when it has no current context, such as the admin kubeconfig of ARO.
+func ParseKubeconfig(input []byte) (*Kubeconfig, error) {
+	var file kubeconfigFile
+	if err := yaml.Unmarshal(input, &file); err != nil {
+		return nil, fmt.Errorf("parsing kubeconfig: %+v", err)
+	}
+
+	if len(file.Clusters) == 0 {
+		return nil, fmt.Errorf("parsing kubeconfig: no cluster found")
+	}
+
+	clusterName, userName := file.Clusters[0].Name, ""
+	if len(file.Users) > 0 {
+		userName = file.Users[0].Name
+	}
+	for _, c := range file.Contexts {
+		if c.Name == file.CurrentContext {
+			clusterName, userName = c.Context.Cluster, c.Context.User
+		}
+	}
+
+	kubeconfig := &Kubeconfig{}
+	found := false
+	for _, c := range file.Clusters {
+		if c.Name != clusterName {
+			continue
+		}
+		found = true
+
+		caCertificate, err := base64.StdEncoding.DecodeString(c.Cluster.CertificateAuthorityData)
+		if err != nil {
+			return nil, fmt.Errorf("decoding the certificate authority data of cluster %q: %+v", c.Name, err)
+		}
+
+		kubeconfig.Host = c.Cluster.Server
+		kubeconfig.ClusterCACertificate = string(caCertificate)
+		kubeconfig.Insecure = c.Cluster.InsecureSkipTLSVerify
+	}
+	if !found {
+		return nil, fmt.Errorf("parsing kubeconfig: cluster %q not found", clusterName)
+	}
+
+	for _, u := range file.Users {
+		if u.Name != userName {
+			continue
+		}
+
+		clientCertificate, err := base64.StdEncoding.DecodeString(u.User.ClientCertificateData)
+		if err != nil {
+			return nil, fmt.Errorf("decoding the client certificate data of user %q: %+v", u.Name, err)
+		}
+		clientKey, err := base64.StdEncoding.DecodeString(u.User.ClientKeyData)
+		if err != nil {
+			return nil, fmt.Errorf("decoding the client key data of user %q: %+v", u.Name, err)
+		}
+
+		kubeconfig.ClientCertificate = string(clientCertificate)
+		kubeconfig.ClientKey = string(clientKey)
+		kubeconfig.Token = u.User.Token
+	}
+
+	return kubeconfig, nil
+}
@@ -0,0 +1,95 @@
+package aro_test
+
+import (
+	"encoding/base64"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/aro"
+)
+
+var _ = Describe("Kubeconfig Test", func() {
+
+	encode := func(s string) string {
+		return base64.StdEncoding.EncodeToString([]byte(s))
+	}
+
+	Context("When parsing an admin kubeconfig without a current context", func() {
+		It("Should return the first cluster and user", func() {
+			kubeconfig, err := aro.ParseKubeconfig([]byte(`apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://api.abcdefgh.eastus.aroapp.io:6443
+    certificate-authority-data: ` + encode("CA") + `
+  name: cluster
+users:
+- name: system:admin
+  user:
+    client-certificate-data: ` + encode("CERT") + `
+    client-key-data: ` + encode("KEY") + `
+`))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(*kubeconfig).Should(Equal(aro.Kubeconfig{
+				Host:                 "https://api.abcdefgh.eastus.aroapp.io:6443",
+				ClusterCACertificate: "CA",
+				ClientCertificate:    "CERT",
+				ClientKey:            "KEY",
+			}))
+		})
+	})
+
+	Context("When parsing a kubeconfig with a current context", func() {
+		It("Should return the cluster and user of the context", func() {
+			kubeconfig, err := aro.ParseKubeconfig([]byte(`current-context: second
+clusters:
+- name: one
+  cluster:
+    server: https://one:6443
+- name: two
+  cluster:
+    server: https://two:6443
+    insecure-skip-tls-verify: true
+users:
+- name: alice
+  user:
+    token: alice-token
+- name: bob
+  user:
+    token: bob-token
+contexts:
+- name: first
+  context:
+    cluster: one
+    user: alice
+- name: second
+  context:
+    cluster: two
+    user: bob
+`))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(kubeconfig.Host).Should(Equal("https://two:6443"))
+			Ω(kubeconfig.Insecure).Should(BeTrue())
+			Ω(kubeconfig.Token).Should(Equal("bob-token"))
+		})
+	})
+
+	Context("When parsing an invalid kubeconfig", func() {
+		It("Should return an error without any cluster", func() {
+			_, err := aro.ParseKubeconfig([]byte(`users: []`))
+			Ω(err).Should(HaveOccurred())
+		})
+		It("Should return an error with invalid certificate data", func() {
+			_, err := aro.ParseKubeconfig([]byte(`clusters:
+- name: one
+  cluster:
+    certificate-authority-data: "not base64!"
+`))
+			Ω(err).Should(HaveOccurred())
+		})
+		It("Should return an error when it isn't YAML", func() {
+			_, err := aro.ParseKubeconfig([]byte(`{`))
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+})
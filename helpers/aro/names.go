@@ -0,0 +1,66 @@
+package aro
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+)
+
+const (
+	domainLetters = "abcdefghijklmnopqrstuvwxyz"
+	domainChars   = "abcdefghijklmnopqrstuvwxyz1234567890"
+
+	// generatedDomainLength matches the length of the domains generated by previous releases
+	generatedDomainLength = 8
+	// prefixedDomainSuffixLength keeps the domains generated for a prefix unique
+	prefixedDomainSuffixLength = 5
+)
+
+// NewSeed returns a random seed from which the cluster's domain and resource group names are derived.  It holds 128
+// bits from crypto/rand, so names derived from different seeds are effectively unique.
+func NewSeed() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating seed: %+v", err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// DomainName derives the cluster's domain from the seed.  Without a prefix the domain is a letter followed by seven
+// letters or digits, otherwise it is the prefix followed by a dash and five letters or digits.
+func DomainName(seed string, prefix string) string {
+	if prefix != "" {
+		return fmt.Sprintf("%s-%s", prefix, derive(seed, "domain", "", prefixedDomainSuffixLength))
+	}
+
+	return derive(seed, "domain", domainLetters, generatedDomainLength)
+}
+
+// ResourceGroupName derives the name of the resource group ARO creates for the cluster's resources from the seed.
+func ResourceGroupName(seed string) string {
+	return fmt.Sprintf("aro-%s", derive(seed, "resourceGroup", domainLetters, generatedDomainLength))
+}
+
+// derive deterministically maps the seed to size characters from domainChars, the first of which is taken from
+// first when it isn't empty.  The purpose keeps the names derived from the same seed independent of each other.
+func derive(seed string, purpose string, first string, size int) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s/%s", purpose, seed)))
+	n := new(big.Int).SetBytes(sum[:])
+
+	result := make([]byte, size)
+	for i := range result {
+		charSet := domainChars
+		if i == 0 && first != "" {
+			charSet = first
+		}
+
+		m := new(big.Int)
+		n.DivMod(n, big.NewInt(int64(len(charSet))), m)
+		result[i] = charSet[m.Int64()]
+	}
+
+	return string(result)
+}
@@ -0,0 +1,56 @@
+package aro_test
+
+import (
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/aro"
+)
+
+var _ = Describe("Names Test", func() {
+
+	var seed string
+
+	BeforeEach(func() {
+		seed = "0123456789abcdef0123456789abcdef"
+	})
+
+	Context("When generating a seed", func() {
+		It("Should return a different seed every time", func() {
+			first, err := aro.NewSeed()
+			Ω(err).ShouldNot(HaveOccurred())
+			second, err := aro.NewSeed()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(first).Should(HaveLen(32))
+			Ω(first).ShouldNot(Equal(second))
+		})
+	})
+
+	Context("When deriving a domain without a prefix", func() {
+		It("Should return a letter followed by seven letters or digits", func() {
+			Ω(aro.DomainName(seed, "")).Should(MatchRegexp(`^[a-z][a-z0-9]{7}$`))
+		})
+		It("Should return the same domain for the same seed", func() {
+			Ω(aro.DomainName(seed, "")).Should(Equal(aro.DomainName(seed, "")))
+		})
+		It("Should return different domains for different seeds", func() {
+			Ω(aro.DomainName(seed, "")).ShouldNot(Equal(aro.DomainName("fedcba9876543210fedcba9876543210", "")))
+		})
+	})
+
+	Context("When deriving a domain with a prefix", func() {
+		It("Should return the prefix followed by five letters or digits", func() {
+			Ω(aro.DomainName(seed, "prod")).Should(MatchRegexp(`^prod-[a-z0-9]{5}$`))
+		})
+	})
+
+	Context("When deriving a resource group name", func() {
+		It("Should return an aro prefixed name independent of the domain", func() {
+			name := aro.ResourceGroupName(seed)
+			Ω(regexp.MustCompile(`^aro-[a-z][a-z0-9]{7}$`).MatchString(name)).Should(BeTrue())
+			Ω(name).ShouldNot(Equal("aro-" + aro.DomainName(seed, "")))
+			Ω(name).Should(Equal(aro.ResourceGroupName(seed)))
+		})
+	})
+})
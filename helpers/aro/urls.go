@@ -0,0 +1,28 @@
+package aro
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ClusterDomain returns the fully qualified domain of a cluster.  A domain without any dot is managed by ARO, which
+// qualifies it with the location, e.g. `abcdefgh` in `eastus` is `abcdefgh.eastus.aroapp.io`, while a custom domain is
+// used as is.
+func ClusterDomain(domain string, location string) string {
+	if strings.Contains(domain, ".") {
+		return domain
+	}
+
+	location = strings.ToLower(strings.ReplaceAll(location, " ", ""))
+	return fmt.Sprintf("%s.%s.aroapp.io", domain, location)
+}
+
+// ConsoleURL returns the URL of the web console of a cluster with the given domain.
+func ConsoleURL(domain string, location string) string {
+	return fmt.Sprintf("https://console-openshift-console.apps.%s/", ClusterDomain(domain, location))
+}
+
+// APIServerURL returns the URL of the API server of a cluster with the given domain.
+func APIServerURL(domain string, location string) string {
+	return fmt.Sprintf("https://api.%s:6443/", ClusterDomain(domain, location))
+}
@@ -0,0 +1,32 @@
+package aro_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/aro"
+)
+
+var _ = Describe("URLs Test", func() {
+
+	Context("When building the URLs of a cluster with a managed domain", func() {
+		It("Should qualify the domain with the location", func() {
+			Ω(aro.ClusterDomain("abcdefgh", "eastus")).Should(Equal("abcdefgh.eastus.aroapp.io"))
+		})
+		It("Should normalize the display name of the location", func() {
+			Ω(aro.ClusterDomain("abcdefgh", "East US")).Should(Equal("abcdefgh.eastus.aroapp.io"))
+		})
+		It("Should return the console URL", func() {
+			Ω(aro.ConsoleURL("abcdefgh", "eastus")).Should(Equal("https://console-openshift-console.apps.abcdefgh.eastus.aroapp.io/"))
+		})
+		It("Should return the API server URL", func() {
+			Ω(aro.APIServerURL("abcdefgh", "eastus")).Should(Equal("https://api.abcdefgh.eastus.aroapp.io:6443/"))
+		})
+	})
+
+	Context("When building the URLs of a cluster with a custom domain", func() {
+		It("Should use the domain as is", func() {
+			Ω(aro.ConsoleURL("aro.example.com", "eastus")).Should(Equal("https://console-openshift-console.apps.aro.example.com/"))
+			Ω(aro.APIServerURL("aro.example.com", "eastus")).Should(Equal("https://api.aro.example.com:6443/"))
+		})
+	})
+})
@@ -0,0 +1,188 @@
+package aroerrors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+// CloudError is the error returned by Azure Resource Manager and the Red Hat OpenShift resource provider, along with
+// any nested details.
+type CloudError struct {
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Target  string       `json:"target,omitempty"`
+	Details []CloudError `json:"details,omitempty"`
+}
+
+// cloudErrorResponse covers both the error body of a failed request and the status of a failed long running
+// operation, which wrap the CloudError in an `error` property.
+type cloudErrorResponse struct {
+	Error *CloudError `json:"error"`
+}
+
+// Unpack returns the *azcore.ResponseError wrapped by err along with the CloudError from its body.  The CloudError is
+// nil when the body couldn't be parsed.
+func Unpack(err error) (*azcore.ResponseError, *CloudError, bool) {
+	var responseError *azcore.ResponseError
+	if !errors.As(err, &responseError) {
+		return nil, nil, false
+	}
+
+	if responseError.RawResponse == nil {
+		return responseError, nil, true
+	}
+
+	body, readErr := runtime.Payload(responseError.RawResponse)
+	if readErr != nil || len(body) == 0 {
+		return responseError, nil, true
+	}
+
+	var response cloudErrorResponse
+	if err := json.Unmarshal(body, &response); err == nil && response.Error != nil && response.Error.Code != "" {
+		return responseError, response.Error, true
+	}
+
+	var cloudError CloudError
+	if err := json.Unmarshal(body, &cloudError); err == nil && cloudError.Code != "" {
+		return responseError, &cloudError, true
+	}
+
+	return responseError, nil, true
+}
+
+// Flatten returns the CloudError along with all of its nested details, depth first.
+func (e *CloudError) Flatten() []CloudError {
+	if e == nil {
+		return nil
+	}
+
+	errs := []CloudError{*e}
+	for i := range e.Details {
+		errs = append(errs, e.Details[i].Flatten()...)
+	}
+
+	return errs
+}
+
+// Diagnostic is an error returned by the ARO resource provider, translated into a summary, a hint on how to fix it and
+// the attribute of the cluster it's about.  It's independent of any schema, so that each resource can point it at its
+// own attributes.
+type Diagnostic struct {
+	Summary string
+	Detail  string
+
+	// Attribute names the attribute the error is about, from the root of the cluster's schema, or is empty when it isn't
+	// about any attribute.  The blocks leading to the attribute hold a single element, so they're named without an index.
+	Attribute []string
+}
+
+// Diagnostics converts err into diagnostics, translating the error codes returned by the ARO resource provider into a
+// summary, the offending attribute and a hint on how to fix it.  Errors without a known code are returned as a single
+// diagnostic with the given summary.
+func Diagnostics(summary string, err error) []Diagnostic {
+	if err == nil {
+		return nil
+	}
+
+	var diags []Diagnostic
+	if _, cloudError, ok := Unpack(err); ok {
+		seen := make(map[string]bool)
+		for _, e := range cloudError.Flatten() {
+			known, ok := knownErrors[e.Code]
+			if !ok || seen[e.Code] {
+				continue
+			}
+			seen[e.Code] = true
+
+			diags = append(diags, known.diagnostic(summary, e, err))
+		}
+	}
+
+	if len(diags) == 0 {
+		diags = append(diags, Diagnostic{
+			Summary: summary,
+			Detail:  err.Error(),
+		})
+	}
+
+	return diags
+}
+
+// knownError describes how to fix an error code returned by the ARO resource provider.
+type knownError struct {
+	summary     string
+	attribute   []string
+	remediation string
+}
+
+func (k knownError) diagnostic(summary string, e CloudError, err error) Diagnostic {
+	detail := []string{
+		fmt.Sprintf("%s: %s", e.Code, e.Message),
+		k.remediation,
+		err.Error(),
+	}
+
+	return Diagnostic{
+		Summary:   fmt.Sprintf("%s: %s", summary, k.summary),
+		Detail:    strings.Join(detail, "\n\n"),
+		Attribute: k.attribute,
+	}
+}
+
+var knownErrors = map[string]knownError{
+	"InvalidLinkedVNet": {
+		summary:   "the virtual network is not valid for the cluster",
+		attribute: []string{"master_profile", "subnet_id"},
+		remediation: "Check that the master and worker subnets are in the same virtual network and region as the " +
+			"cluster, are not used by any other resource, and that both the cluster service principal and the Azure " +
+			"Red Hat OpenShift RP service principal have the Network Contributor role on the virtual network.",
+	},
+	"InvalidServicePrincipalCredentials": {
+		summary:   "the service principal credentials are invalid",
+		attribute: []string{"service_principal", "client_secret"},
+		remediation: "Check that `service_principal.client_id` and `service_principal.client_secret` belong to the " +
+			"same application and that the secret has not expired. Newly created secrets can take a few minutes to " +
+			"propagate, so retrying the apply may succeed.",
+	},
+	"InvalidServicePrincipalPermissions": {
+		summary:   "the service principal is missing permissions",
+		attribute: []string{"service_principal", "client_id"},
+		remediation: "Grant the cluster service principal the Network Contributor role on the virtual network, and on " +
+			"any route table or NAT gateway attached to the subnets.",
+	},
+	"InvalidResourceProviderPermissions": {
+		summary:   "the Azure Red Hat OpenShift RP is missing permissions",
+		attribute: []string{"master_profile", "subnet_id"},
+		remediation: "Grant the Azure Red Hat OpenShift RP service principal the Network Contributor role on the " +
+			"virtual network, and on any route table or NAT gateway attached to the subnets.",
+	},
+	"ResourceQuotaExceeded": {
+		summary:   "the subscription quota has been exceeded",
+		attribute: []string{"worker_profile", "vm_size"},
+		remediation: "Request a quota increase for the VM family in the cluster's region, or reduce " +
+			"`worker_profile.node_count` or choose a smaller `vm_size`.",
+	},
+	"DuplicateDomain": {
+		summary:   "the cluster domain is already in use",
+		attribute: []string{"cluster_profile", "domain"},
+		remediation: "Choose a different `cluster_profile.domain`, or omit it to have a unique domain generated for " +
+			"the cluster.",
+	},
+	"DuplicateResourceGroup": {
+		summary:   "the cluster resource group already exists",
+		attribute: []string{"cluster_resource_group"},
+		remediation: "The resource group holding the cluster's resources is created by ARO and must not exist " +
+			"beforehand. Choose a different `cluster_resource_group` or delete the existing resource group.",
+	},
+	"RequestDisallowedByPolicy": {
+		summary: "the request was denied by an Azure Policy assignment",
+		remediation: "Review the policy named in the error with your Azure Policy administrators. Policies assigned to " +
+			"the subscription, the cluster's resource group or the cluster resource group also apply to the " +
+			"resources ARO creates on your behalf.",
+	},
+}
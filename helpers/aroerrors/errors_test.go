@@ -0,0 +1,169 @@
+package aroerrors
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+func responseError(statusCode int, body string) error {
+	resp := &http.Response{
+		StatusCode: statusCode,
+		Status:     http.StatusText(statusCode),
+		Header:     http.Header{},
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+		Request: &http.Request{
+			Method: http.MethodPut,
+			URL:    &url.URL{Scheme: "https", Host: "management.azure.com", Path: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.RedHatOpenShift/openShiftClusters/tf-openshift"},
+		},
+	}
+	return runtime.NewResponseError(resp)
+}
+
+func TestUnpack(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		err          error
+		expectedOk   bool
+		expectedCode string
+	}{
+		{
+			desc:       "Errors which aren't response errors are not unpacked",
+			err:        fmt.Errorf("connection reset by peer"),
+			expectedOk: false,
+		},
+		{
+			desc:         "Error bodies are unpacked",
+			err:          responseError(http.StatusBadRequest, `{"error":{"code":"InvalidLinkedVNet","message":"The provided subnet is invalid."}}`),
+			expectedOk:   true,
+			expectedCode: "InvalidLinkedVNet",
+		},
+		{
+			desc:         "Failed long running operations are unpacked",
+			err:          responseError(http.StatusOK, `{"id":"op","status":"Failed","error":{"code":"ResourceQuotaExceeded","message":"Quota exceeded."}}`),
+			expectedOk:   true,
+			expectedCode: "ResourceQuotaExceeded",
+		},
+		{
+			desc:         "Unwrapped error bodies are unpacked",
+			err:          responseError(http.StatusConflict, `{"code":"DuplicateDomain","message":"The domain is in use."}`),
+			expectedOk:   true,
+			expectedCode: "DuplicateDomain",
+		},
+		{
+			desc:         "Wrapped response errors are unpacked",
+			err:          fmt.Errorf("creating cluster: %w", responseError(http.StatusConflict, `{"code":"DuplicateDomain","message":"The domain is in use."}`)),
+			expectedOk:   true,
+			expectedCode: "DuplicateDomain",
+		},
+		{
+			desc:       "Response errors without a JSON body are unpacked without a cloud error",
+			err:        responseError(http.StatusBadGateway, `<html>Bad Gateway</html>`),
+			expectedOk: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			_, cloudError, ok := Unpack(test.err)
+			if ok != test.expectedOk {
+				t.Fatalf("Expected ok to be %t - got %t", test.expectedOk, ok)
+			}
+
+			code := ""
+			if cloudError != nil {
+				code = cloudError.Code
+			}
+			if code != test.expectedCode {
+				t.Fatalf("Expected code %q - got %q", test.expectedCode, code)
+			}
+		})
+	}
+}
+
+func TestDiagnostics(t *testing.T) {
+	testCases := []struct {
+		desc              string
+		err               error
+		expectedSummary   []string
+		expectedAttribute [][]string
+		expectedInDetail  []string
+	}{
+		{
+			desc:              "Unknown errors are returned as is",
+			err:               fmt.Errorf("connection reset by peer"),
+			expectedSummary:   []string{"creating cluster"},
+			expectedAttribute: [][]string{nil},
+			expectedInDetail:  []string{"connection reset by peer"},
+		},
+		{
+			desc:              "Unknown codes are returned as is",
+			err:               responseError(http.StatusBadRequest, `{"error":{"code":"SomethingElse","message":"Something else went wrong."}}`),
+			expectedSummary:   []string{"creating cluster"},
+			expectedAttribute: [][]string{nil},
+			expectedInDetail:  []string{"SomethingElse"},
+		},
+		{
+			desc:              "Known codes are translated",
+			err:               responseError(http.StatusBadRequest, `{"error":{"code":"InvalidServicePrincipalCredentials","message":"The provided service principal credentials are invalid."}}`),
+			expectedSummary:   []string{"creating cluster: the service principal credentials are invalid"},
+			expectedAttribute: [][]string{{"service_principal", "client_secret"}},
+			expectedInDetail:  []string{"The provided service principal credentials are invalid.", "has not expired"},
+		},
+		{
+			desc: "Known codes in the details are translated",
+			err: responseError(http.StatusBadRequest, `{"error":{"code":"InvalidParameter","message":"Validation failed.","details":[
+				{"code":"InvalidLinkedVNet","message":"The provided subnet is invalid."},
+				{"code":"RequestDisallowedByPolicy","message":"Resource was disallowed by policy 'deny-public-ip'."}
+			]}}`),
+			expectedSummary: []string{
+				"creating cluster: the virtual network is not valid for the cluster",
+				"creating cluster: the request was denied by an Azure Policy assignment",
+			},
+			expectedAttribute: [][]string{
+				{"master_profile", "subnet_id"},
+				nil,
+			},
+			expectedInDetail: []string{"The provided subnet is invalid.", "deny-public-ip"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			diags := Diagnostics("creating cluster", test.err)
+			if len(diags) != len(test.expectedSummary) {
+				t.Fatalf("Expected %d diagnostics - got %d: %+v", len(test.expectedSummary), len(diags), diags)
+			}
+
+			detail := ""
+			for i, d := range diags {
+				if d.Summary != test.expectedSummary[i] {
+					t.Errorf("Expected summary %q - got %q", test.expectedSummary[i], d.Summary)
+				}
+				if !reflect.DeepEqual(d.Attribute, test.expectedAttribute[i]) {
+					t.Errorf("Expected attribute %q - got %q", test.expectedAttribute[i], d.Attribute)
+				}
+				detail += d.Detail
+			}
+
+			for _, expected := range test.expectedInDetail {
+				if !strings.Contains(detail, expected) {
+					t.Errorf("Expected %q in the detail %q", expected, detail)
+				}
+			}
+		})
+	}
+}
+
+func TestDiagnostics_Nil(t *testing.T) {
+	if diags := Diagnostics("creating cluster", nil); diags != nil {
+		t.Fatalf("Expected no diagnostics - got %+v", diags)
+	}
+}
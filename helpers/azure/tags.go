@@ -2,14 +2,68 @@ package azure
 
 import (
 	"fmt"
+	"strings"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/utils"
 )
 
-func TagsExpand(tagsMap map[string]interface{}) map[string]*string {
-	output := make(map[string]*string, len(tagsMap))
+// TagsConfig holds the provider's `default_tags`, which are merged into the tags of every resource, and `ignore_tags`,
+// whose keys are never read back from Azure.
+type TagsConfig struct {
+	DefaultTags       map[string]interface{}
+	IgnoreKeys        []string
+	IgnoreKeyPrefixes []string
+}
+
+// Ignored returns true when the tag key matches one of the ignored keys or key prefixes.
+func (c TagsConfig) Ignored(key string) bool {
+	if utils.SliceContainsValue(c.IgnoreKeys, key) {
+		return true
+	}
+
+	for _, prefix := range c.IgnoreKeyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MergeDefaults returns the default tags overridden by the resource's tags.
+func (c TagsConfig) MergeDefaults(tagsMap map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{}, len(c.DefaultTags)+len(tagsMap))
+
+	for k, v := range c.DefaultTags {
+		output[k] = v
+	}
+
+	for k, v := range tagsMap {
+		output[k] = v
+	}
+
+	return output
+}
+
+// TagsAll returns the effective tags of a resource, i.e. its tags merged with the default tags, without the ignored
+// tags.
+func (c TagsConfig) TagsAll(tagsMap map[string]interface{}) map[string]interface{} {
+	output := c.MergeDefaults(tagsMap)
+
+	for k := range output {
+		if c.Ignored(k) {
+			delete(output, k)
+		}
+	}
+
+	return output
+}
 
-	for i, v := range tagsMap {
+func TagsExpand(tagsMap map[string]interface{}, config TagsConfig) map[string]*string {
+	merged := config.MergeDefaults(tagsMap)
+	output := make(map[string]*string, len(merged))
+
+	for i, v := range merged {
 		// Validate should have ignored this error already
 		value, _ := TagValueToString(v)
 		output[i] = &value
@@ -18,13 +72,43 @@ func TagsExpand(tagsMap map[string]interface{}) map[string]*string {
 	return output
 }
 
-func TagsFlattenAndSet(d *schema.ResourceData, tagMap map[string]*string) error {
-	flattened := Flatten(tagMap)
-	if err := d.Set("tags", flattened); err != nil {
-		return fmt.Errorf("setting `tags`: %s", err)
+// TagsFlatten returns the tags found in Azure as `tags_all`, without the ignored tags, and as `tags`, less those which
+// only come from the default tags and aren't configured.
+func TagsFlatten(tagMap map[string]*string, configured map[string]interface{}, config TagsConfig) (tags, tagsAll map[string]interface{}) {
+	tagsAll = Flatten(tagMap)
+	for k := range tagsAll {
+		if config.Ignored(k) {
+			delete(tagsAll, k)
+		}
+	}
+
+	tags = make(map[string]interface{}, len(tagsAll))
+	for k, v := range tagsAll {
+		if defaultValue, ok := config.DefaultTags[k]; ok && defaultValue == v {
+			if _, ok := configured[k]; !ok {
+				continue
+			}
+		}
+
+		tags[k] = v
+	}
+
+	return tags, tagsAll
+}
+
+// TagsEqual returns true when both maps hold the same tags.
+func TagsEqual(a, b map[string]interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for k, v := range a {
+		if w, ok := b[k]; !ok || fmt.Sprint(v) != fmt.Sprint(w) {
+			return false
+		}
 	}
 
-	return nil
+	return true
 }
 
 func Flatten(tagMap map[string]*string) map[string]interface{} {
@@ -0,0 +1,130 @@
+package azure_test
+
+import (
+	"testing"
+
+	"github.com/rh-mobb/terraform-provider-azureopenshift/helpers/azure"
+)
+
+func TestTagsConfig_Ignored(t *testing.T) {
+	config := azure.TagsConfig{
+		IgnoreKeys:        []string{"created-by"},
+		IgnoreKeyPrefixes: []string{"policy:"},
+	}
+
+	cases := []struct {
+		Key     string
+		Ignored bool
+	}{
+		{
+			Key:     "created-by",
+			Ignored: true,
+		},
+		{
+			Key:     "created-by-team",
+			Ignored: false,
+		},
+		{
+			Key:     "policy:compliance",
+			Ignored: true,
+		},
+		{
+			Key:     "owner",
+			Ignored: false,
+		},
+	}
+
+	for _, tc := range cases {
+		if ignored := config.Ignored(tc.Key); ignored != tc.Ignored {
+			t.Fatalf("Expected Ignored(%q) to be %t, got %t", tc.Key, tc.Ignored, ignored)
+		}
+	}
+}
+
+func TestTagsExpand_MergesDefaultTags(t *testing.T) {
+	config := azure.TagsConfig{
+		DefaultTags: map[string]interface{}{
+			"owner":       "platform",
+			"environment": "dev",
+		},
+	}
+
+	tags := azure.TagsExpand(map[string]interface{}{
+		"environment": "prod",
+		"app":         "aro",
+	}, config)
+
+	expected := map[string]string{
+		"owner":       "platform",
+		"environment": "prod",
+		"app":         "aro",
+	}
+
+	if len(tags) != len(expected) {
+		t.Fatalf("Expected %d tags, got %d: %+v", len(expected), len(tags), tags)
+	}
+
+	for k, v := range expected {
+		if tags[k] == nil || *tags[k] != v {
+			t.Fatalf("Expected tag %q to be %q, got %v", k, v, tags[k])
+		}
+	}
+}
+
+func TestTagsConfig_TagsAll(t *testing.T) {
+	config := azure.TagsConfig{
+		DefaultTags: map[string]interface{}{
+			"owner":        "platform",
+			"policy:audit": "true",
+		},
+		IgnoreKeyPrefixes: []string{"policy:"},
+	}
+
+	tagsAll := config.TagsAll(map[string]interface{}{
+		"app": "aro",
+	})
+
+	expected := map[string]interface{}{
+		"owner": "platform",
+		"app":   "aro",
+	}
+
+	if !azure.TagsEqual(tagsAll, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, tagsAll)
+	}
+}
+
+func TestTagsFlatten(t *testing.T) {
+	config := azure.TagsConfig{
+		DefaultTags: map[string]interface{}{
+			"owner":       "platform",
+			"environment": "dev",
+		},
+		IgnoreKeyPrefixes: []string{"policy:"},
+	}
+
+	platform, prod, aro, audit := "platform", "prod", "aro", "true"
+	tags, tagsAll := azure.TagsFlatten(map[string]*string{
+		"owner":        &platform,
+		"environment":  &prod,
+		"app":          &aro,
+		"policy:audit": &audit,
+	}, map[string]interface{}{"environment": "prod", "app": "aro"}, config)
+
+	expectedTags := map[string]interface{}{
+		"environment": "prod",
+		"app":         "aro",
+	}
+	if !azure.TagsEqual(tags, expectedTags) {
+		t.Fatalf("Expected tags %+v, got %+v", expectedTags, tags)
+	}
+
+	expectedTagsAll := map[string]interface{}{
+		"owner":       "platform",
+		"environment": "prod",
+		"app":         "aro",
+	}
+	if !azure.TagsEqual(tagsAll, expectedTagsAll) {
+		t.Fatalf("Expected tags_all %+v, got %+v", expectedTagsAll, tagsAll)
+	}
+}
@@ -0,0 +1,153 @@
+package locks
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// mutexKV is a simple key/value store of single slot semaphores, used to serialize changes across resources which
+// share a parent, such as clusters in the same virtual network.  Semaphores are used rather than mutexes so that
+// waiting for a lock stops when the operation is cancelled or times out.
+type mutexKV struct {
+	lock  sync.Mutex
+	store map[string]Semaphore
+}
+
+var armMutexKV = &mutexKV{
+	store: make(map[string]Semaphore),
+}
+
+// get returns the semaphore for the given key, creating it if needed.  Keys are case insensitive as ARM IDs are.
+func (m *mutexKV) get(key string) Semaphore {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	key = strings.ToLower(key)
+	mutex, ok := m.store[key]
+	if !ok {
+		mutex = NewSemaphore(1)
+		m.store[key] = mutex
+	}
+	return mutex
+}
+
+// ByID locks the given ID until UnlockByID is called, returning an error if ctx is done first.
+func ByID(ctx context.Context, id string) error {
+	log.Printf("[DEBUG] Locking %q", id)
+	if err := armMutexKV.get(id).Acquire(ctx); err != nil {
+		return fmt.Errorf("waiting for the lock on %q: %w", id, err)
+	}
+	log.Printf("[DEBUG] Locked %q", id)
+	return nil
+}
+
+// UnlockByID unlocks an ID locked by ByID.
+func UnlockByID(id string) {
+	log.Printf("[DEBUG] Unlocking %q", id)
+	armMutexKV.get(id).Release()
+	log.Printf("[DEBUG] Unlocked %q", id)
+}
+
+// MultipleByID locks all of the given IDs.  The IDs are deduplicated and locked in a consistent order so resources
+// locking overlapping sets of IDs can't deadlock.  If ctx is done first the IDs already locked are unlocked and an
+// error is returned.
+func MultipleByID(ctx context.Context, ids []string) error {
+	normalised := normalise(ids)
+	for i, id := range normalised {
+		if err := ByID(ctx, id); err != nil {
+			UnlockMultipleByID(normalised[:i])
+			return err
+		}
+	}
+	return nil
+}
+
+// UnlockMultipleByID unlocks IDs locked by MultipleByID.
+func UnlockMultipleByID(ids []string) {
+	normalised := normalise(ids)
+	for i := len(normalised) - 1; i >= 0; i-- {
+		UnlockByID(normalised[i])
+	}
+}
+
+func normalise(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		key := strings.ToLower(id)
+		if id == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, id)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return strings.ToLower(result[i]) < strings.ToLower(result[j])
+	})
+
+	return result
+}
+
+// subscriptionSemaphores holds a semaphore per subscription, so that the concurrent operations in a subscription are
+// capped across every provider configured for it, such as the aliases of a configuration.
+var subscriptionSemaphores = struct {
+	lock  sync.Mutex
+	store map[string]Semaphore
+}{
+	store: make(map[string]Semaphore),
+}
+
+// BySubscription returns the semaphore allowing size concurrent operations in the subscription, creating it if needed.
+// Subscription IDs are case insensitive, and the size given first for a subscription is kept.
+func BySubscription(subscriptionId string, size int) Semaphore {
+	subscriptionSemaphores.lock.Lock()
+	defer subscriptionSemaphores.lock.Unlock()
+
+	key := strings.ToLower(subscriptionId)
+	semaphore, ok := subscriptionSemaphores.store[key]
+	if !ok {
+		semaphore = NewSemaphore(size)
+		subscriptionSemaphores.store[key] = semaphore
+	} else if cap(semaphore) != max(size, 0) {
+		log.Printf("[WARN] %q already allows %d concurrent operations, ignoring the limit of %d", subscriptionId, cap(semaphore), size)
+	}
+	return semaphore
+}
+
+// Semaphore caps the number of concurrent operations.  A nil Semaphore doesn't limit concurrency.
+type Semaphore chan struct{}
+
+// NewSemaphore returns a Semaphore allowing size concurrent operations, or nil when size is 0.
+func NewSemaphore(size int) Semaphore {
+	if size <= 0 {
+		return nil
+	}
+	return make(Semaphore, size)
+}
+
+// Acquire waits for a free slot, returning an error if ctx is done first.
+func (s Semaphore) Acquire(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release frees a slot taken by Acquire.
+func (s Semaphore) Release() {
+	if s == nil {
+		return
+	}
+	<-s
+}
@@ -0,0 +1,129 @@
+package locks
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNormalise(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		input    []string
+		expected []string
+	}{
+		{"Empty", []string{}, []string{}},
+		{"Empty IDs are skipped", []string{"", "/b"}, []string{"/b"}},
+		{"IDs are sorted", []string{"/b", "/a"}, []string{"/a", "/b"}},
+		{"IDs are deduplicated ignoring case", []string{"/vnet/A", "/vnet/a", "/vnet/b"}, []string{"/vnet/A", "/vnet/b"}},
+	}
+
+	for _, test := range testCases {
+		if result := normalise(test.input); !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("Expected %v for case '%s' - got %v", test.expected, test.desc, result)
+		}
+	}
+}
+
+func TestMultipleByID_SerializesOverlappingIDs(t *testing.T) {
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+
+	var wg sync.WaitGroup
+	for _, ids := range [][]string{{"/vnet/a", "/vnet/b"}, {"/vnet/b", "/vnet/a"}, {"/vnet/A"}} {
+		wg.Add(1)
+		go func(ids []string) {
+			defer wg.Done()
+
+			if err := MultipleByID(context.Background(), ids); err != nil {
+				t.Errorf("Expected to lock %v - got %+v", ids, err)
+				return
+			}
+			defer UnlockMultipleByID(ids)
+
+			mu.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}(ids)
+	}
+	wg.Wait()
+
+	if maxRunning != 1 {
+		t.Fatalf("Expected operations on the same IDs to be serialized - got %d running at once", maxRunning)
+	}
+}
+
+func TestMultipleByID_Cancelled(t *testing.T) {
+	if err := MultipleByID(context.Background(), []string{"/vnet/c"}); err != nil {
+		t.Fatalf("Expected to lock a free ID - got %+v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := MultipleByID(ctx, []string{"/vnet/b", "/vnet/c"}); err == nil {
+		t.Fatalf("Expected to time out waiting for a locked ID")
+	}
+
+	// the IDs locked before timing out are released
+	UnlockMultipleByID([]string{"/vnet/c"})
+	if err := MultipleByID(context.Background(), []string{"/vnet/b", "/vnet/c"}); err != nil {
+		t.Fatalf("Expected to lock the released IDs - got %+v", err)
+	}
+	UnlockMultipleByID([]string{"/vnet/b", "/vnet/c"})
+}
+
+func TestSemaphore(t *testing.T) {
+	s := NewSemaphore(1)
+	if err := s.Acquire(context.Background()); err != nil {
+		t.Fatalf("Expected to acquire a free slot - got %+v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := s.Acquire(ctx); err == nil {
+		t.Fatalf("Expected to time out waiting for a slot")
+	}
+
+	s.Release()
+	if err := s.Acquire(context.Background()); err != nil {
+		t.Fatalf("Expected to acquire a released slot - got %+v", err)
+	}
+}
+
+func TestSemaphore_Unlimited(t *testing.T) {
+	s := NewSemaphore(0)
+	for i := 0; i < 10; i++ {
+		if err := s.Acquire(context.Background()); err != nil {
+			t.Fatalf("Expected an unlimited semaphore to never block - got %+v", err)
+		}
+	}
+	s.Release()
+}
+
+func TestBySubscription(t *testing.T) {
+	s := BySubscription("AAAAAAAA-0000-0000-0000-000000000000", 1)
+	if other := BySubscription("aaaaaaaa-0000-0000-0000-000000000000", 2); other != s {
+		t.Fatalf("Expected the semaphore of the subscription to be shared ignoring case")
+	}
+	if cap(s) != 1 {
+		t.Fatalf("Expected the first size given for the subscription to be kept - got %d", cap(s))
+	}
+
+	if other := BySubscription("bbbbbbbb-0000-0000-0000-000000000000", 1); other == s {
+		t.Fatalf("Expected another subscription to get its own semaphore")
+	}
+	if other := BySubscription("cccccccc-0000-0000-0000-000000000000", 0); other != nil {
+		t.Fatalf("Expected an unlimited semaphore for a size of 0")
+	}
+}
@@ -0,0 +1,37 @@
+package tf
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+)
+
+// suppressEquivalentString keeps the value in state when the planned value is equivalent to it, as the
+// DiffSuppressFunc of an SDKv2 attribute did.
+type suppressEquivalentString struct {
+	equivalent func(old, new string) bool
+}
+
+// SuppressEquivalentString plans the value in state rather than an equivalent value, such as one differing in case.
+// It must come before any RequiresReplace plan modifier, so that equivalent values don't replace the resource.
+func SuppressEquivalentString(equivalent func(old, new string) bool) planmodifier.String {
+	return suppressEquivalentString{equivalent: equivalent}
+}
+
+func (m suppressEquivalentString) Description(_ context.Context) string {
+	return "Keeps the value in state when the configured value is equivalent to it."
+}
+
+func (m suppressEquivalentString) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m suppressEquivalentString) PlanModifyString(_ context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || req.StateValue.IsUnknown() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	if m.equivalent(req.StateValue.ValueString(), req.PlanValue.ValueString()) {
+		resp.PlanValue = req.StateValue
+	}
+}
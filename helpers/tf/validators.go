@@ -0,0 +1,108 @@
+package tf
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ValidateFunc is the signature of the validation functions shared with the SDKv2 provider, such as those of
+// helpers/validate.
+type ValidateFunc func(interface{}, string) ([]string, []error)
+
+// validateFuncValidator runs a ValidateFunc as a framework validator, so the resources served by the framework
+// validate their attributes as the SDKv2 ones did.
+type validateFuncValidator struct {
+	f ValidateFunc
+}
+
+var (
+	_ validator.String = validateFuncValidator{}
+	_ validator.Int64  = validateFuncValidator{}
+	_ validator.Map    = validateFuncValidator{}
+)
+
+// StringValidator validates a string attribute with f.
+func StringValidator(f ValidateFunc) validator.String {
+	return validateFuncValidator{f: f}
+}
+
+// Int64Validator validates a number attribute with f, which is given an int as SDKv2 does.
+func Int64Validator(f ValidateFunc) validator.Int64 {
+	return validateFuncValidator{f: f}
+}
+
+// MapValidator validates a map of strings with f, which is given a map[string]interface{} as SDKv2 does.  The map
+// isn't validated until all of its values are known.
+func MapValidator(f ValidateFunc) validator.Map {
+	return validateFuncValidator{f: f}
+}
+
+func (v validateFuncValidator) Description(_ context.Context) string {
+	return "value must be valid"
+}
+
+func (v validateFuncValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v validateFuncValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	v.validate(req.ConfigValue.ValueString(), req.Path.String(), func(warning string) {
+		resp.Diagnostics.AddAttributeWarning(req.Path, "Attribute Warning", warning)
+	}, func(err error) {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Attribute Value", err.Error())
+	})
+}
+
+func (v validateFuncValidator) ValidateInt64(_ context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	v.validate(int(req.ConfigValue.ValueInt64()), req.Path.String(), func(warning string) {
+		resp.Diagnostics.AddAttributeWarning(req.Path, "Attribute Warning", warning)
+	}, func(err error) {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Attribute Value", err.Error())
+	})
+}
+
+func (v validateFuncValidator) ValidateMap(ctx context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	input := make(map[string]interface{}, len(req.ConfigValue.Elements()))
+	for k, e := range req.ConfigValue.Elements() {
+		s, ok := e.(types.String)
+		if !ok {
+			resp.Diagnostics.AddAttributeError(req.Path, "Invalid Attribute Value", fmt.Sprintf("expected the value of %q to be a string, got %T", k, e))
+			return
+		}
+		if s.IsUnknown() {
+			return
+		}
+		input[k] = s.ValueString()
+	}
+
+	v.validate(input, req.Path.String(), func(warning string) {
+		resp.Diagnostics.AddAttributeWarning(req.Path, "Attribute Warning", warning)
+	}, func(err error) {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Attribute Value", err.Error())
+	})
+}
+
+func (v validateFuncValidator) validate(input interface{}, key string, warn func(string), fail func(error)) {
+	warnings, errs := v.f(input, key)
+	for _, w := range warnings {
+		warn(w)
+	}
+	for _, err := range errs {
+		fail(err)
+	}
+}
@@ -1,50 +1,145 @@
 package utils
 
 import (
+	"context"
+	"errors"
+	"io"
 	"net"
 	"net/http"
+	"strconv"
+	"syscall"
+	"time"
 
-	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 )
 
-func ResponseWasNotFound(resp autorest.Response) bool {
-	return ResponseWasStatusCode(resp, http.StatusNotFound)
+// retryableStatusCodes are the statuses returned for transient failures, such as a conflicting operation on a shared
+// virtual network, throttling, or an internal error in the resource provider
+var retryableStatusCodes = map[int]bool{
+	http.StatusConflict:            true,
+	http.StatusTooManyRequests:     true,
+	http.StatusInternalServerError: true,
+	http.StatusBadGateway:          true,
+	http.StatusServiceUnavailable:  true,
+	http.StatusGatewayTimeout:      true,
 }
 
-func ResponseWasBadRequest(resp autorest.Response) bool {
-	return ResponseWasStatusCode(resp, http.StatusBadRequest)
+// nonRetryableErrorCodes are returned with one of the retryableStatusCodes but will never succeed when retried
+var nonRetryableErrorCodes = map[string]bool{
+	"DuplicateDomain":           true,
+	"DuplicateResourceGroup":    true,
+	"RequestDisallowedByPolicy": true,
+	"ResourceQuotaExceeded":     true,
+	"ScopeLocked":               true,
 }
 
-func ResponseWasForbidden(resp autorest.Response) bool {
-	return ResponseWasStatusCode(resp, http.StatusForbidden)
+// retryableNetworkErrors are returned when a connection is dropped mid-request, such as by a load balancer or proxy
+var retryableNetworkErrors = []error{
+	syscall.ECONNRESET,
+	syscall.ECONNABORTED,
+	syscall.EPIPE,
+	io.ErrUnexpectedEOF,
 }
 
-func ResponseWasConflict(resp autorest.Response) bool {
-	return ResponseWasStatusCode(resp, http.StatusConflict)
+func ResponseWasNotFound(err error) bool {
+	return ResponseWasStatusCode(err, http.StatusNotFound)
 }
 
+func ResponseWasBadRequest(err error) bool {
+	return ResponseWasStatusCode(err, http.StatusBadRequest)
+}
+
+func ResponseWasForbidden(err error) bool {
+	return ResponseWasStatusCode(err, http.StatusForbidden)
+}
+
+func ResponseWasConflict(err error) bool {
+	return ResponseWasStatusCode(err, http.StatusConflict)
+}
+
+// ResponseWasDeniedByDenyAssignment returns true when the request was refused because of a deny assignment, such as
+// the one ARO places on the resource group holding the cluster's resources
+func ResponseWasDeniedByDenyAssignment(err error) bool {
+	var responseError *azcore.ResponseError
+	if errors.As(err, &responseError) {
+		return responseError.StatusCode == http.StatusForbidden && responseError.ErrorCode == "DenyAssignmentAuthorizationFailed"
+	}
+
+	return false
+}
+
+// ResponseErrorIsRetryable returns true for timeouts and dropped connections, and for responses with a status
+// indicating a transient failure.  Requests whose context was cancelled or timed out are never retried, although the
+// context's deadline is reported as a network timeout
 func ResponseErrorIsRetryable(err error) bool {
-	if arerr, ok := err.(autorest.DetailedError); ok {
-		err = arerr.Original
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var responseError *azcore.ResponseError
+	if errors.As(err, &responseError) {
+		return retryableStatusCodes[responseError.StatusCode] && !nonRetryableErrorCodes[responseError.ErrorCode]
 	}
 
-	// nolint gocritic
-	switch e := err.(type) {
-	case net.Error:
-		if e.Temporary() || e.Timeout() {
+	for _, networkErr := range retryableNetworkErrors {
+		if errors.Is(err, networkErr) {
 			return true
 		}
 	}
 
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
 	return false
 }
 
-func ResponseWasStatusCode(resp autorest.Response, statusCode int) bool { // nolint: unparam
-	if r := resp.Response; r != nil {
-		if r.StatusCode == statusCode {
-			return true
-		}
+// ResponseWasStatusCode returns true when err, or an error it wraps, is an *azcore.ResponseError with the given
+// status code
+func ResponseWasStatusCode(err error, statusCode int) bool { // nolint: unparam
+	var responseError *azcore.ResponseError
+	if errors.As(err, &responseError) {
+		return responseError.StatusCode == statusCode
 	}
 
 	return false
 }
+
+// ResponseRetryAfter returns the delay requested by the `Retry-After` header of the response wrapped by err, or 0 when
+// no delay was requested
+func ResponseRetryAfter(err error) time.Duration {
+	var responseError *azcore.ResponseError
+	if !errors.As(err, &responseError) || responseError.RawResponse == nil {
+		return 0
+	}
+
+	header := responseError.RawResponse.Header
+	for _, name := range []string{"retry-after-ms", "x-ms-retry-after-ms"} {
+		if v := header.Get(name); v != "" {
+			if ms, err := strconv.Atoi(v); err == nil && ms > 0 {
+				return time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+
+	v := header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(v); err == nil {
+		if seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		return 0
+	}
+
+	if at, err := http.ParseTime(v); err == nil {
+		if d := time.Until(at); d > 0 {
+			return d
+		}
+	}
+
+	return 0
+}
@@ -1,16 +1,22 @@
 package utils
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"net/url"
+	"os"
+	"syscall"
 	"testing"
+	"time"
 
-	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 )
 
 func TestResponseNotFound_DroppedConnection(t *testing.T) {
-	resp := autorest.Response{}
-	if ResponseWasNotFound(resp) {
+	if ResponseWasNotFound(fmt.Errorf("connection reset by peer")) {
 		t.Fatalf("responseWasNotFound should return `false` for a dropped connection")
 	}
 }
@@ -26,12 +32,10 @@ func TestResponseNotFound_StatusCodes(t *testing.T) {
 	}
 
 	for _, test := range testCases {
-		resp := autorest.Response{
-			Response: &http.Response{
-				StatusCode: test.statusCode,
-			},
+		err := &azcore.ResponseError{
+			StatusCode: test.statusCode,
 		}
-		result := ResponseWasNotFound(resp)
+		result := ResponseWasNotFound(err)
 		if test.expectedResult != result {
 			t.Fatalf("Expected '%+v' for status code '%d' - got '%+v'",
 				test.expectedResult, test.statusCode, result)
@@ -39,6 +43,32 @@ func TestResponseNotFound_StatusCodes(t *testing.T) {
 	}
 }
 
+func TestResponseNotFound_Wrapped(t *testing.T) {
+	err := fmt.Errorf("retrieving cluster: %w", &azcore.ResponseError{StatusCode: http.StatusNotFound})
+	if !ResponseWasNotFound(err) {
+		t.Fatalf("responseWasNotFound should return `true` for a wrapped 404 response error")
+	}
+}
+
+func TestResponseWasDeniedByDenyAssignment(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		err            error
+		expectedResult bool
+	}{
+		{"Deny assignments are detected", &azcore.ResponseError{StatusCode: http.StatusForbidden, ErrorCode: "DenyAssignmentAuthorizationFailed"}, true},
+		{"Wrapped deny assignments are detected", fmt.Errorf("tagging: %w", &azcore.ResponseError{StatusCode: http.StatusForbidden, ErrorCode: "DenyAssignmentAuthorizationFailed"}), true},
+		{"Missing role assignments are not deny assignments", &azcore.ResponseError{StatusCode: http.StatusForbidden, ErrorCode: "AuthorizationFailed"}, false},
+		{"Other errors are not deny assignments", fmt.Errorf("Some other error"), false},
+	}
+
+	for _, test := range testCases {
+		if result := ResponseWasDeniedByDenyAssignment(test.err); result != test.expectedResult {
+			t.Fatalf("%s: expected '%+v' - got '%+v'", test.desc, test.expectedResult, result)
+		}
+	}
+}
+
 type testNetError struct {
 	timeout   bool
 	temporary bool
@@ -58,15 +88,26 @@ func TestResponseErrorIsRetryable(t *testing.T) {
 		{"Unhandled error types are not retryable", fmt.Errorf("Some other error"), false},
 		{"Temporary AND timeout errors are retryable", testNetError{true, true}, true},
 		{"Timeout errors are retryable", testNetError{true, false}, true},
-		{"Temporary errors are retryable", testNetError{false, true}, true},
+		{"Temporary errors which are not timeouts are not retryable", testNetError{false, true}, false},
 		{"net.Errors that are neither temporary nor timeouts are not retryable", testNetError{false, false}, false},
-		{"Retryable error nested in autorest.DetailedError is retryable", autorest.DetailedError{
-			Original: testNetError{true, true},
-		}, true},
-		{"Unhandled error nested in autorest.DetailedError is not retryable", autorest.DetailedError{
-			Original: fmt.Errorf("Some other error"),
-		}, false},
+		{"Reset connections are retryable", &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}, true},
+		{"Broken pipes are retryable", fmt.Errorf("sending request: %w", syscall.EPIPE), true},
+		{"Truncated responses are retryable", fmt.Errorf("reading response: %w", io.ErrUnexpectedEOF), true},
+		{"Refused connections are not retryable", &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}, false},
+		{"Retryable error wrapped in another error is retryable", fmt.Errorf("sending request: %w", testNetError{true, true}), true},
+		{"Unhandled error wrapped in another error is not retryable", fmt.Errorf("sending request: %w", fmt.Errorf("Some other error")), false},
 		{"nil is handled as non-retryable", nil, false},
+		{"Conflicts are retryable", &azcore.ResponseError{StatusCode: http.StatusConflict}, true},
+		{"Throttled requests are retryable", &azcore.ResponseError{StatusCode: http.StatusTooManyRequests}, true},
+		{"Internal server errors are retryable", &azcore.ResponseError{StatusCode: http.StatusInternalServerError, ErrorCode: "InternalServerError"}, true},
+		{"Wrapped internal server errors are retryable", fmt.Errorf("creating cluster: %w", &azcore.ResponseError{StatusCode: http.StatusInternalServerError}), true},
+		{"Bad requests are not retryable", &azcore.ResponseError{StatusCode: http.StatusBadRequest, ErrorCode: "InvalidLinkedVNet"}, false},
+		{"Not found is not retryable", &azcore.ResponseError{StatusCode: http.StatusNotFound}, false},
+		{"Conflicts which will never succeed are not retryable", &azcore.ResponseError{StatusCode: http.StatusConflict, ErrorCode: "DuplicateDomain"}, false},
+		{"Requests refused by a management lock are not retryable", &azcore.ResponseError{StatusCode: http.StatusConflict, ErrorCode: "ScopeLocked"}, false},
+		{"Expired contexts are not retryable", context.DeadlineExceeded, false},
+		{"Requests whose context expired are not retryable", &url.Error{Op: "Get", URL: "https://management.azure.com", Err: context.DeadlineExceeded}, false},
+		{"Cancelled contexts are not retryable", fmt.Errorf("sending request: %w", context.Canceled), false},
 	}
 
 	for _, test := range testCases {
@@ -77,3 +118,32 @@ func TestResponseErrorIsRetryable(t *testing.T) {
 		}
 	}
 }
+
+func TestResponseRetryAfter(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		header   http.Header
+		expected time.Duration
+	}{
+		{"No header", http.Header{}, 0},
+		{"Seconds", http.Header{"Retry-After": []string{"30"}}, 30 * time.Second},
+		{"Milliseconds", http.Header{"Retry-After-Ms": []string{"1500"}}, 1500 * time.Millisecond},
+		{"Milliseconds are preferred", http.Header{"X-Ms-Retry-After-Ms": []string{"500"}, "Retry-After": []string{"30"}}, 500 * time.Millisecond},
+		{"Dates in the past are ignored", http.Header{"Retry-After": []string{"Wed, 21 Oct 2015 07:28:00 GMT"}}, 0},
+		{"Invalid values are ignored", http.Header{"Retry-After": []string{"soon"}}, 0},
+	}
+
+	for _, test := range testCases {
+		err := &azcore.ResponseError{
+			StatusCode:  http.StatusTooManyRequests,
+			RawResponse: &http.Response{Header: test.header},
+		}
+		if result := ResponseRetryAfter(err); result != test.expected {
+			t.Errorf("Expected '%v' for case '%s' - got '%v'", test.expected, test.desc, result)
+		}
+	}
+
+	if result := ResponseRetryAfter(fmt.Errorf("Some other error")); result != 0 {
+		t.Errorf("Expected no delay for errors without a response - got '%v'", result)
+	}
+}
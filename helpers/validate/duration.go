@@ -0,0 +1,25 @@
+package validate
+
+import (
+	"fmt"
+	"time"
+)
+
+// Duration validates that the string can be parsed by time.ParseDuration and is not negative
+func Duration(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return nil, []error{fmt.Errorf("%q must be a valid duration such as `30s` or `2m`: %v", k, err)}
+	}
+
+	if d < 0 {
+		return nil, []error{fmt.Errorf("%q must not be a negative duration, got %q", k, v)}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,45 @@
+package validate
+
+import (
+	"testing"
+)
+
+func TestDuration(t *testing.T) {
+	cases := []struct {
+		Input  string
+		Errors int
+	}{
+		{
+			Input:  "",
+			Errors: 1,
+		},
+		{
+			Input:  "30",
+			Errors: 1,
+		},
+		{
+			Input:  "-1s",
+			Errors: 1,
+		},
+		{
+			Input:  "0s",
+			Errors: 0,
+		},
+		{
+			Input:  "4s",
+			Errors: 0,
+		},
+		{
+			Input:  "1m30s",
+			Errors: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Input, func(t *testing.T) {
+			if _, errors := Duration(tc.Input, "duration"); len(errors) != tc.Errors {
+				t.Fatalf("Expected Duration to have %d not %d errors for %q: %v", tc.Errors, len(errors), tc.Input, errors)
+			}
+		})
+	}
+}
@@ -82,3 +82,20 @@ func ValidateOutBoundType(i interface{}, k string) (warnings []string, errors []
 	}
 	return warnings, errors
 }
+
+// CIDRsOverlap returns whether two CIDR blocks, such as the pod and service CIDRs of a cluster and the address space of
+// its virtual network, have any address in common.
+func CIDRsOverlap(a string, b string) (bool, error) {
+	_, first, err := net.ParseCIDR(a)
+	if err != nil {
+		return false, fmt.Errorf("parsing CIDR %q: %+v", a, err)
+	}
+
+	_, second, err := net.ParseCIDR(b)
+	if err != nil {
+		return false, fmt.Errorf("parsing CIDR %q: %+v", b, err)
+	}
+
+	// two blocks overlap exactly when one of them contains the first address of the other
+	return first.Contains(second.IP) || second.Contains(first.IP), nil
+}
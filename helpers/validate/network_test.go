@@ -234,3 +234,33 @@ func TestPortNumberOrZero(t *testing.T) {
 		})
 	}
 }
+
+func TestCIDRsOverlap(t *testing.T) {
+	cases := []struct {
+		A        string
+		B        string
+		Overlap  bool
+		HasError bool
+	}{
+		{A: "10.0.0.0/16", B: "10.0.1.0/24", Overlap: true},
+		{A: "10.0.1.0/24", B: "10.0.0.0/16", Overlap: true},
+		{A: "10.0.0.0/24", B: "10.0.0.0/24", Overlap: true},
+		{A: "10.128.0.0/14", B: "172.30.0.0/16", Overlap: false},
+		{A: "10.0.0.0/24", B: "10.0.1.0/24", Overlap: false},
+		{A: "10.0.0.0", B: "10.0.1.0/24", HasError: true},
+		{A: "10.0.0.0/24", B: "", HasError: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.A+" "+tc.B, func(t *testing.T) {
+			overlap, err := CIDRsOverlap(tc.A, tc.B)
+
+			if (err != nil) != tc.HasError {
+				t.Fatalf("Expected an error %t, got %+v", tc.HasError, err)
+			}
+			if overlap != tc.Overlap {
+				t.Fatalf("Expected overlap %t, got %t", tc.Overlap, overlap)
+			}
+		})
+	}
+}
@@ -1,15 +1,20 @@
 package main
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
 	"github.com/rh-mobb/terraform-provider-azureopenshift/azureopenshift"
 )
 
 func main() {
-	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: func() *schema.Provider {
-			return azureopenshift.Provider()
-		},
-	})
+	serverFactory, err := azureopenshift.ProtoV5ProviderServerFactory(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := tf5server.Serve("registry.terraform.io/rh-mobb/azureopenshift", serverFactory); err != nil {
+		log.Fatal(err)
+	}
 }